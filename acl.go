@@ -0,0 +1,183 @@
+package goparse
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+const (
+	aclPublic     = "*"
+	aclRolePrefix = "role:"
+)
+
+// ACL data type which controls read and write access to an object
+type ACL struct {
+	permissions map[string]ACLPermission
+}
+
+// ACLPermission is the permission of a user, a role or the public
+type ACLPermission struct {
+	Read  bool `json:"read,omitempty"`
+	Write bool `json:"write,omitempty"`
+}
+
+// NewACL creates an empty ACL which grants no access
+func NewACL() *ACL {
+	return &ACL{
+		permissions: map[string]ACLPermission{},
+	}
+}
+
+// SetPublicRead sets whether the public can read the object
+func (a *ACL) SetPublicRead(allowed bool) *ACL {
+	return a.setRead(aclPublic, allowed)
+}
+
+// SetPublicWrite sets whether the public can write the object
+func (a *ACL) SetPublicWrite(allowed bool) *ACL {
+	return a.setWrite(aclPublic, allowed)
+}
+
+// SetUserRead sets whether the user can read the object
+func (a *ACL) SetUserRead(userID string, allowed bool) *ACL {
+	return a.setRead(userID, allowed)
+}
+
+// SetUserWrite sets whether the user can write the object
+func (a *ACL) SetUserWrite(userID string, allowed bool) *ACL {
+	return a.setWrite(userID, allowed)
+}
+
+// SetRoleRead sets whether the users in the role can read the object
+func (a *ACL) SetRoleRead(roleName string, allowed bool) *ACL {
+	return a.setRead(aclRolePrefix+roleName, allowed)
+}
+
+// SetRoleWrite sets whether the users in the role can write the object
+func (a *ACL) SetRoleWrite(roleName string, allowed bool) *ACL {
+	return a.setWrite(aclRolePrefix+roleName, allowed)
+}
+
+// PublicRead returns whether the public can read the object
+func (a *ACL) PublicRead() bool {
+	return a.permissions[aclPublic].Read
+}
+
+// PublicWrite returns whether the public can write the object
+func (a *ACL) PublicWrite() bool {
+	return a.permissions[aclPublic].Write
+}
+
+// UserRead returns whether the user can read the object
+func (a *ACL) UserRead(userID string) bool {
+	return a.permissions[userID].Read
+}
+
+// UserWrite returns whether the user can write the object
+func (a *ACL) UserWrite(userID string) bool {
+	return a.permissions[userID].Write
+}
+
+// RoleRead returns whether the users in the role can read the object
+func (a *ACL) RoleRead(roleName string) bool {
+	return a.permissions[aclRolePrefix+roleName].Read
+}
+
+// RoleWrite returns whether the users in the role can write the object
+func (a *ACL) RoleWrite(roleName string) bool {
+	return a.permissions[aclRolePrefix+roleName].Write
+}
+
+// Set the read permission of the key
+func (a *ACL) setRead(key string, allowed bool) *ACL {
+	p := a.permissions[key]
+	p.Read = allowed
+	return a.set(key, p)
+}
+
+// Set the write permission of the key
+func (a *ACL) setWrite(key string, allowed bool) *ACL {
+	p := a.permissions[key]
+	p.Write = allowed
+	return a.set(key, p)
+}
+
+// Copy the ACL so that the changes of the copy do not affect the ACL
+func (a *ACL) copy() *ACL {
+	c := NewACL()
+	for key, p := range a.permissions {
+		c.permissions[key] = p
+	}
+	return c
+}
+
+// Set the permission of the key, and remove it if it grants nothing
+func (a *ACL) set(key string, p ACLPermission) *ACL {
+	if a.permissions == nil {
+		a.permissions = map[string]ACLPermission{}
+	}
+	if !p.Read && !p.Write {
+		delete(a.permissions, key)
+	} else {
+		a.permissions[key] = p
+	}
+	return a
+}
+
+// MarshalJSON encodes ACL as the map of the permissions
+func (a ACL) MarshalJSON() ([]byte, error) {
+	if a.permissions == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(a.permissions)
+}
+
+// UnmarshalJSON decodes ACL from the map of the permissions
+func (a *ACL) UnmarshalJSON(b []byte) error {
+	permissions := map[string]ACLPermission{}
+	if err := json.Unmarshal(b, &permissions); err != nil {
+		return err
+	}
+	a.permissions = permissions
+	return nil
+}
+
+// Set the default ACL of the session to data if data has no ACL.
+// The data which is nil is regarded as an empty object.
+func (s *ParseSession) withDefaultACL(data interface{}) (interface{}, error) {
+	if s.DefaultACL == nil {
+		return data, nil
+	}
+
+	var b []byte
+	switch v := data.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		var err error
+		if b, err = json.Marshal(data); err != nil {
+			return nil, err
+		}
+	}
+
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		if _, ok := err.(*json.UnmarshalTypeError); ok {
+			return nil, errors.New("data must be a JSON object")
+		}
+		return nil, err
+	}
+	if m == nil {
+		m = map[string]json.RawMessage{}
+	}
+	if _, ok := m["ACL"]; !ok {
+		acl, err := json.Marshal(s.DefaultACL)
+		if err != nil {
+			return nil, err
+		}
+		m["ACL"] = acl
+	}
+	return m, nil
+}
@@ -0,0 +1,165 @@
+package goparse
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestACL(t *testing.T) {
+
+	Convey("Given an ACL", t, func() {
+
+		acl := NewACL().
+			SetPublicRead(true).
+			SetUserRead("abc", true).
+			SetUserWrite("abc", true).
+			SetRoleWrite("admin", true)
+
+		Convey("It has the permissions", func() {
+			So(acl.PublicRead(), ShouldBeTrue)
+			So(acl.PublicWrite(), ShouldBeFalse)
+			So(acl.UserRead("abc"), ShouldBeTrue)
+			So(acl.UserWrite("abc"), ShouldBeTrue)
+			So(acl.RoleRead("admin"), ShouldBeFalse)
+			So(acl.RoleWrite("admin"), ShouldBeTrue)
+			So(acl.UserRead("def"), ShouldBeFalse)
+		})
+
+		Convey("When encoding", func() {
+
+			b, err := json.Marshal(acl)
+			So(err, ShouldBeNil)
+
+			Convey("It is the map of the permissions", func() {
+				So(string(b), ShouldEqual, `{"*":{"read":true},"abc":{"read":true,"write":true},"role:admin":{"write":true}}`)
+			})
+		})
+
+		Convey("When revoking all the permissions of a user", func() {
+
+			acl.SetUserRead("abc", false).SetUserWrite("abc", false)
+			b, _ := json.Marshal(acl)
+
+			Convey("It removes the user", func() {
+				So(string(b), ShouldEqual, `{"*":{"read":true},"role:admin":{"write":true}}`)
+			})
+		})
+
+		Convey("When encoding a zero ACL", func() {
+
+			var zero ACL
+			b, _ := json.Marshal(zero)
+
+			Convey("It is an empty map", func() {
+				So(string(b), ShouldEqual, `{}`)
+				So(zero.PublicRead(), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given JSON strings", t, func() {
+
+		Convey("When decoding as ACL", func() {
+
+			s := `{"*":{"read":true},"abc":{"write":true},"role:admin":{"read":true,"write":true}}`
+
+			var acl ACL
+			err := json.Unmarshal([]byte(s), &acl)
+			So(err, ShouldBeNil)
+
+			Convey("It has the permissions", func() {
+				So(acl.PublicRead(), ShouldBeTrue)
+				So(acl.UserWrite("abc"), ShouldBeTrue)
+				So(acl.UserRead("abc"), ShouldBeFalse)
+				So(acl.RoleRead("admin"), ShouldBeTrue)
+				So(acl.RoleWrite("admin"), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a session with the default ACL", t, func() {
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			RESTAPIKey:    "APIKEY",
+		})
+		So(err, ShouldBeNil)
+
+		session := client.NewSession("")
+		session.DefaultACL = NewACL().SetPublicRead(true)
+
+		Convey("When the data has no ACL", func() {
+
+			data, err := session.withDefaultACL(struct {
+				Name string `json:"name"`
+				Code int64  `json:"code"`
+			}{"apple", 201})
+			So(err, ShouldBeNil)
+			b, _ := json.Marshal(data)
+
+			Convey("It sets the default ACL", func() {
+				So(string(b), ShouldEqual, `{"ACL":{"*":{"read":true}},"code":201,"name":"apple"}`)
+			})
+		})
+
+		Convey("When the data is a JSON string", func() {
+
+			data, err := session.withDefaultACL(`{"name":"apple"}`)
+			So(err, ShouldBeNil)
+			b, _ := json.Marshal(data)
+
+			Convey("It sets the default ACL", func() {
+				So(string(b), ShouldEqual, `{"ACL":{"*":{"read":true}},"name":"apple"}`)
+			})
+		})
+
+		Convey("When the data has an ACL", func() {
+
+			data, err := session.withDefaultACL(map[string]interface{}{
+				"name": "apple",
+				"ACL":  NewACL().SetUserRead("abc", true),
+			})
+			So(err, ShouldBeNil)
+			b, _ := json.Marshal(data)
+
+			Convey("It keeps the ACL", func() {
+				So(string(b), ShouldEqual, `{"ACL":{"abc":{"read":true}},"name":"apple"}`)
+			})
+		})
+
+		Convey("When the data is nil", func() {
+
+			data, err := session.withDefaultACL(nil)
+			So(err, ShouldBeNil)
+			b, _ := json.Marshal(data)
+
+			Convey("It sets the default ACL to an empty object", func() {
+				So(string(b), ShouldEqual, `{"ACL":{"*":{"read":true}}}`)
+			})
+		})
+
+		Convey("When the data is not a JSON object", func() {
+
+			_, err := session.withDefaultACL([]string{"apple"})
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "data must be a JSON object")
+			})
+		})
+
+		Convey("When the default ACL is not set", func() {
+
+			session.DefaultACL = nil
+			data := map[string]interface{}{"name": "apple"}
+			result, err := session.withDefaultACL(data)
+
+			Convey("It returns the data as it is", func() {
+				So(err, ShouldBeNil)
+				So(result, ShouldResemble, data)
+			})
+		})
+	})
+}
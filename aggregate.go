@@ -0,0 +1,129 @@
+package goparse
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+)
+
+// Aggregate is an aggregate pipeline builder for the ParseClass.
+// Aggregate queries are always sent with the master key.
+type Aggregate struct {
+	class    *ParseClass
+	pipeline []map[string]interface{}
+	hint     interface{}
+}
+
+// NewAggregate creates a new aggregate pipeline from the class
+func (c *ParseClass) NewAggregate() *Aggregate {
+	return &Aggregate{
+		class:    c,
+		pipeline: []map[string]interface{}{},
+	}
+}
+
+// Stage adds a stage such as "$match" or "$group" to the pipeline
+func (a *Aggregate) Stage(name string, value interface{}) *Aggregate {
+	a.pipeline = append(a.pipeline, map[string]interface{}{
+		name: value,
+	})
+	return a
+}
+
+// Match adds a $match stage
+func (a *Aggregate) Match(where map[string]interface{}) *Aggregate {
+	return a.Stage("$match", where)
+}
+
+// Group adds a $group stage
+func (a *Aggregate) Group(group map[string]interface{}) *Aggregate {
+	return a.Stage("$group", group)
+}
+
+// Project adds a $project stage
+func (a *Aggregate) Project(project map[string]interface{}) *Aggregate {
+	return a.Stage("$project", project)
+}
+
+// Sort adds a $sort stage
+func (a *Aggregate) Sort(sort map[string]interface{}) *Aggregate {
+	return a.Stage("$sort", sort)
+}
+
+// Limit adds a $limit stage
+func (a *Aggregate) Limit(n int) *Aggregate {
+	return a.Stage("$limit", n)
+}
+
+// Skip adds a $skip stage
+func (a *Aggregate) Skip(n int) *Aggregate {
+	return a.Stage("$skip", n)
+}
+
+// Unwind adds a $unwind stage
+func (a *Aggregate) Unwind(path string) *Aggregate {
+	return a.Stage("$unwind", path)
+}
+
+// Hint sets the index name or the index specification to use
+func (a *Aggregate) Hint(hint interface{}) *Aggregate {
+	a.hint = hint
+	return a
+}
+
+// Pipeline returns the stages of the pipeline
+func (a *Aggregate) Pipeline() []map[string]interface{} {
+	return a.pipeline
+}
+
+// Find runs the pipeline into the provided slice such as *[]T
+func (a *Aggregate) Find(results interface{}) error {
+	b, err := json.Marshal(a.pipeline)
+	if err != nil {
+		return err
+	}
+	vals := url.Values{
+		"pipeline": []string{string(b)},
+	}
+	if a.hint != nil {
+		b, err := json.Marshal(a.hint)
+		if err != nil {
+			return err
+		}
+		vals.Set("hint", string(b))
+	}
+	return a.class.aggregate(vals, results)
+}
+
+// Distinct gets the distinct values of the key into the provided slice such as *[]T.
+// The where conditions may be nil.
+func (c *ParseClass) Distinct(key string, where map[string]interface{}, results interface{}) error {
+	vals := url.Values{
+		"distinct": []string{key},
+	}
+	if len(where) > 0 {
+		b, err := json.Marshal(where)
+		if err != nil {
+			return err
+		}
+		vals.Set("where", string(b))
+	}
+	return c.aggregate(vals, results)
+}
+
+// Distinct gets the distinct values of the key in the data which matches the query
+func (q *Query) Distinct(key string, results interface{}) error {
+	if q.err != nil {
+		return q.err
+	}
+	return q.class.Distinct(key, q.where, results)
+}
+
+// Run an aggregate request by encoded query values
+func (c *ParseClass) aggregate(vals url.Values, results interface{}) error {
+	if c.Session.client.MasterKey == "" {
+		return errors.New("aggregate requires MasterKey")
+	}
+	path := "/aggregate/" + c.Name + "?" + vals.Encode()
+	return do(c.Session.get(path, true), &ListResponse{Results: results})
+}
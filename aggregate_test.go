@@ -0,0 +1,95 @@
+package goparse
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAggregate(t *testing.T) {
+
+	Convey("Given a class", t, func() {
+
+		var path string
+		var query url.Values
+		var header http.Header
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			query = r.URL.Query()
+			header = r.Header
+			w.Write([]byte(`{"results":[{"objectId":"apple","total":3},{"objectId":"melon","total":1}]}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			MasterKey:     "MASTERKEY",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		testingClass := client.NewSession("").NewClass("Testdata")
+
+		type Total struct {
+			ObjectID string `json:"objectId"`
+			Total    int    `json:"total"`
+		}
+
+		Convey("When running a pipeline", func() {
+
+			var results []Total
+			err := testingClass.NewAggregate().
+				Match(map[string]interface{}{"code": map[string]interface{}{"$gt": 200}}).
+				Group(map[string]interface{}{"objectId": "$name", "total": map[string]interface{}{"$sum": 1}}).
+				Sort(map[string]interface{}{"total": -1}).
+				Limit(10).
+				Hint("name_1").
+				Find(&results)
+
+			Convey("It returns no errors", func() {
+				So(err, ShouldBeNil)
+				So(results, ShouldResemble, []Total{{"apple", 3}, {"melon", 1}})
+			})
+
+			Convey("It sends the pipeline with the master key", func() {
+				So(path, ShouldEqual, "/aggregate/Testdata")
+				So(query.Get("pipeline"), ShouldEqual, `[`+
+					`{"$match":{"code":{"$gt":200}}},`+
+					`{"$group":{"objectId":"$name","total":{"$sum":1}}},`+
+					`{"$sort":{"total":-1}},`+
+					`{"$limit":10}]`)
+				So(query.Get("hint"), ShouldEqual, `"name_1"`)
+				So(header.Get(headerMasterKey), ShouldEqual, "MASTERKEY")
+			})
+		})
+
+		Convey("When getting distinct values", func() {
+
+			var results []interface{}
+			err := testingClass.NewQuery().GreaterThan("code", 200).Distinct("name", &results)
+
+			Convey("It sends the distinct field", func() {
+				So(err, ShouldBeNil)
+				So(query.Get("distinct"), ShouldEqual, "name")
+				So(query.Get("where"), ShouldEqual, `{"code":{"$gt":200}}`)
+			})
+		})
+
+		Convey("When the master key is empty", func() {
+
+			client.MasterKey = ""
+			var results []Total
+			err := testingClass.NewAggregate().Limit(1).Find(&results)
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "aggregate requires MasterKey")
+				So(path, ShouldBeEmpty)
+			})
+		})
+	})
+}
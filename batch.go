@@ -0,0 +1,201 @@
+package goparse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// batchLimit is the maximum number of operations in a batch request
+const batchLimit = 50
+
+// Batch collects create, update and delete operations to send them at once.
+// If Transaction is true, all the operations are committed or rolled back together.
+type Batch struct {
+	session     *ParseSession
+	UseMaster   bool
+	Transaction bool
+	requests    []batchRequest
+	err         error
+}
+
+// batchRequest is an operation in the batch request
+type batchRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Body   interface{} `json:"body,omitempty"`
+}
+
+// BatchResult is the result of an operation in the batch.
+// Either Success or Error is set.
+type BatchResult struct {
+	Success *ObjectResponse `json:"success,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// BatchError is the error of the operation which failed in the transactional batch.
+// Index is -1 if the server did not report which operation failed.
+type BatchError struct {
+	Index int
+	Err   *Error
+}
+
+// Error to string
+func (err *BatchError) Error() string {
+	return "batch operation " + strconv.Itoa(err.Index) + " failed: " + err.Err.Error()
+}
+
+// NewBatch creates a new batch from the session
+func (s *ParseSession) NewBatch() *Batch {
+	return &Batch{
+		session: s,
+	}
+}
+
+// Len returns the number of operations in the batch
+func (b *Batch) Len() int {
+	return len(b.requests)
+}
+
+// Create adds an operation which creates class data
+func (b *Batch) Create(class *ParseClass, data interface{}) *Batch {
+	data, err := class.Session.withDefaultACL(data)
+	if err != nil {
+		return b.fail(err)
+	}
+	return b.add("POST", class.ClassURL, data)
+}
+
+// Update adds an operation which updates class data by ID
+func (b *Batch) Update(class *ParseClass, objectID string, data interface{}) *Batch {
+	if objectID == "" {
+		return b.fail(errors.New("ObjectID must not be empty"))
+	}
+	return b.add("PUT", class.ClassURL+"/"+objectID, data)
+}
+
+// Delete adds an operation which deletes class data by ID
+func (b *Batch) Delete(class *ParseClass, objectID string) *Batch {
+	if objectID == "" {
+		return b.fail(errors.New("ObjectID must not be empty"))
+	}
+	return b.add("DELETE", class.ClassURL+"/"+objectID, nil)
+}
+
+// Add an operation to the batch
+func (b *Batch) add(method string, path string, body interface{}) *Batch {
+	b.requests = append(b.requests, batchRequest{
+		Method: method,
+		Path:   path,
+		Body:   body,
+	})
+	return b
+}
+
+// Keep the first error to return it from Send
+func (b *Batch) fail(err error) *Batch {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// Send sends the operations in chunks of the batch limit and returns the results in the order of the operations.
+// If a request fails, the results of the chunks sent before are returned with the error.
+// A transactional batch is sent in a single request, and returns *BatchError if an operation fails.
+func (b *Batch) Send() ([]BatchResult, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.Transaction {
+		return b.sendTransaction()
+	}
+
+	results := make([]BatchResult, 0, len(b.requests))
+	for start := 0; start < len(b.requests); start += batchLimit {
+		end := start + batchLimit
+		if end > len(b.requests) {
+			end = len(b.requests)
+		}
+		chunk, err := b.chunk(start, end)
+		if err != nil {
+			return results, err
+		}
+
+		var chunkResults []BatchResult
+		body := map[string]interface{}{
+			"requests": chunk,
+		}
+		if err := do(b.session.post("/batch", b.UseMaster).Send(body), &chunkResults); err != nil {
+			return results, err
+		}
+		results = append(results, chunkResults...)
+	}
+	return results, nil
+}
+
+// Send all the operations in a transaction
+func (b *Batch) sendTransaction() ([]BatchResult, error) {
+	if len(b.requests) > batchLimit {
+		return nil, errors.New("transactional batch must not exceed " + strconv.Itoa(batchLimit) + " operations")
+	}
+	chunk, err := b.chunk(0, len(b.requests))
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"requests":    chunk,
+		"transaction": true,
+	}
+	res, resBody, errs := b.session.post("/batch", b.UseMaster).Send(body).End()
+	if errs != nil {
+		return nil, fmt.Errorf("%v", errs)
+	}
+
+	// the results are returned even if the transaction is aborted
+	var results []BatchResult
+	if err := json.Unmarshal([]byte(resBody), &results); err == nil {
+		for i, result := range results {
+			if result.Error != nil {
+				return results, &BatchError{Index: i, Err: result.Error}
+			}
+		}
+		return results, nil
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		reserr := new(Error)
+		if err := json.Unmarshal([]byte(resBody), reserr); err != nil {
+			return nil, err
+		}
+		return nil, &BatchError{Index: -1, Err: reserr}
+	}
+	return nil, errors.New("unexpected batch response: " + resBody)
+}
+
+// Make the requests from start to end with the path prefix of the endpoint URL
+func (b *Batch) chunk(start int, end int) ([]batchRequest, error) {
+	prefix, err := b.session.pathPrefix()
+	if err != nil {
+		return nil, err
+	}
+	chunk := make([]batchRequest, end-start)
+	for i, req := range b.requests[start:end] {
+		req.Path = prefix + req.Path
+		chunk[i] = req
+	}
+	return chunk, nil
+}
+
+// Get the path of the endpoint URL which prefixes the paths in batch requests
+func (s *ParseSession) pathPrefix() (string, error) {
+	u, err := url.Parse(s.client.URL)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(u.Path, "/"), nil
+}
@@ -0,0 +1,199 @@
+package goparse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBatch(t *testing.T) {
+
+	Convey("Given a batch", t, func() {
+
+		var bodies []map[string]interface{}
+		var paths []string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+
+			var body struct {
+				Requests    []batchRequest `json:"requests"`
+				Transaction bool           `json:"transaction"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+
+			var raw map[string]interface{}
+			b, _ := json.Marshal(body)
+			json.Unmarshal(b, &raw)
+			bodies = append(bodies, raw)
+
+			results := make([]map[string]interface{}, len(body.Requests))
+			for i, req := range body.Requests {
+				if req.Method == "DELETE" {
+					results[i] = map[string]interface{}{
+						"error": map[string]interface{}{"code": 101, "error": "object not found for delete"},
+					}
+				} else {
+					results[i] = map[string]interface{}{
+						"success": map[string]interface{}{"objectId": fmt.Sprintf("id%d", len(bodies)*100+i)},
+					}
+				}
+			}
+			if body.Transaction {
+				for _, result := range results {
+					if result["error"] != nil {
+						w.WriteHeader(http.StatusBadRequest)
+						break
+					}
+				}
+			}
+			json.NewEncoder(w).Encode(results)
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			URL:           server.URL + "/parse",
+		})
+		So(err, ShouldBeNil)
+
+		session := client.NewSession("")
+		testingClass := session.NewClass("Testdata")
+		otherClass := session.NewClass("Otherdata")
+
+		Convey("When sending operations across classes", func() {
+
+			batch := session.NewBatch().
+				Create(testingClass, map[string]interface{}{"name": "apple"}).
+				Update(otherClass, "abc", map[string]interface{}{"code": Increment(1)}).
+				Delete(testingClass, "def")
+			So(batch.Len(), ShouldEqual, 3)
+
+			results, err := batch.Send()
+
+			Convey("It posts the operations to /batch", func() {
+				So(err, ShouldBeNil)
+				So(paths, ShouldResemble, []string{"/parse/batch"})
+
+				requests := bodies[0]["requests"].([]interface{})
+				So(requests[0], ShouldResemble, map[string]interface{}{
+					"method": "POST",
+					"path":   "/parse/classes/Testdata",
+					"body":   map[string]interface{}{"name": "apple"},
+				})
+				So(requests[1], ShouldResemble, map[string]interface{}{
+					"method": "PUT",
+					"path":   "/parse/classes/Otherdata/abc",
+					"body":   map[string]interface{}{"code": map[string]interface{}{"__op": "Increment", "amount": float64(1)}},
+				})
+				So(requests[2], ShouldResemble, map[string]interface{}{
+					"method": "DELETE",
+					"path":   "/parse/classes/Testdata/def",
+				})
+			})
+
+			Convey("It returns the results in order", func() {
+				So(len(results), ShouldEqual, 3)
+				So(results[0].Success.ObjectID, ShouldEqual, "id100")
+				So(results[0].Error, ShouldBeNil)
+				So(results[1].Success.ObjectID, ShouldEqual, "id101")
+				So(results[2].Success, ShouldBeNil)
+				So(results[2].Error.Code, ShouldEqual, 101)
+				So(IsObjectNotFound(results[2].Error), ShouldBeTrue)
+			})
+		})
+
+		Convey("When sending more operations than the limit", func() {
+
+			batch := session.NewBatch()
+			for i := 0; i < 120; i++ {
+				batch.Create(testingClass, map[string]interface{}{"code": i})
+			}
+			results, err := batch.Send()
+
+			Convey("It sends them in chunks", func() {
+				So(err, ShouldBeNil)
+				So(len(paths), ShouldEqual, 3)
+				So(len(bodies[0]["requests"].([]interface{})), ShouldEqual, 50)
+				So(len(bodies[1]["requests"].([]interface{})), ShouldEqual, 50)
+				So(len(bodies[2]["requests"].([]interface{})), ShouldEqual, 20)
+			})
+
+			Convey("It returns all the results in order", func() {
+				So(len(results), ShouldEqual, 120)
+				So(results[0].Success.ObjectID, ShouldEqual, "id100")
+				So(results[50].Success.ObjectID, ShouldEqual, "id200")
+				So(results[119].Success.ObjectID, ShouldEqual, "id319")
+			})
+		})
+
+		Convey("When an objectID is empty", func() {
+
+			results, err := session.NewBatch().
+				Create(testingClass, map[string]interface{}{"name": "apple"}).
+				Update(testingClass, "", map[string]interface{}{"name": "melon"}).
+				Send()
+
+			Convey("It returns an error without sending", func() {
+				So(results, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "ObjectID must not be empty")
+				So(paths, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When sending a transaction", func() {
+
+			batch := session.NewBatch().
+				Create(testingClass, map[string]interface{}{"name": "apple"}).
+				Update(otherClass, "abc", map[string]interface{}{"name": "melon"})
+			batch.Transaction = true
+			results, err := batch.Send()
+
+			Convey("It sends the transaction flag", func() {
+				So(err, ShouldBeNil)
+				So(bodies[0]["transaction"], ShouldEqual, true)
+				So(len(results), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When an operation in the transaction fails", func() {
+
+			batch := session.NewBatch().
+				Create(testingClass, map[string]interface{}{"name": "apple"}).
+				Delete(testingClass, "def")
+			batch.Transaction = true
+			results, err := batch.Send()
+
+			Convey("It returns the index and the error", func() {
+				So(err, ShouldNotBeNil)
+				batchErr, ok := err.(*BatchError)
+				So(ok, ShouldBeTrue)
+				So(batchErr.Index, ShouldEqual, 1)
+				So(batchErr.Err.Code, ShouldEqual, 101)
+				So(err.Error(), ShouldEqual, "batch operation 1 failed: object not found for delete - code:101")
+				So(len(results), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When the transaction exceeds the limit", func() {
+
+			batch := session.NewBatch()
+			for i := 0; i < 51; i++ {
+				batch.Create(testingClass, map[string]interface{}{"code": i})
+			}
+			batch.Transaction = true
+			_, err := batch.Send()
+
+			Convey("It returns an error without sending", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "transactional batch must not exceed 50 operations")
+				So(paths, ShouldBeEmpty)
+			})
+		})
+	})
+}
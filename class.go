@@ -14,6 +14,11 @@ type ParseClass struct {
 	UseMaster bool
 }
 
+// countResponse is the response of count queries
+type countResponse struct {
+	Count int `json:"count"`
+}
+
 // Select gets class data information
 func (c *ParseClass) Select(objectID string, result interface{}) error {
 	path := c.ClassURL
@@ -32,12 +37,65 @@ func (c *ParseClass) SelectQuery(query map[string]interface{}, result interface{
 	where := url.Values{
 		"where": []string{string(b)},
 	}
-	path := c.ClassURL + "?" + where.Encode()
+	return c.selectValues(where, result)
+}
+
+// Count counts class data by custom query
+func (c *ParseClass) Count(query map[string]interface{}) (int, error) {
+	vals := url.Values{
+		"limit": []string{"0"},
+	}
+	if len(query) > 0 {
+		b, err := json.Marshal(query)
+		if err != nil {
+			return 0, err
+		}
+		vals.Set("where", string(b))
+	}
+	return c.countValues(vals, nil)
+}
+
+// Get class data by encoded query values
+func (c *ParseClass) selectValues(vals url.Values, result interface{}) error {
+	path := c.ClassURL + "?" + vals.Encode()
 	return do(c.Session.get(path, c.UseMaster), &result)
 }
 
+// Get the number of class data by encoded query values, and the results if result is not nil
+func (c *ParseClass) countValues(vals url.Values, result interface{}) (int, error) {
+	vals.Set("count", "1")
+	var body json.RawMessage
+	if err := c.selectValues(vals, &body); err != nil {
+		return 0, err
+	}
+	var resp countResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, err
+	}
+	if result != nil {
+		if err := json.Unmarshal(body, result); err != nil {
+			return 0, err
+		}
+	}
+	return resp.Count, nil
+}
+
+// SelectList gets class data into the provided slice such as *[]T
+func (c *ParseClass) SelectList(results interface{}) error {
+	return c.Select("", &ListResponse{Results: results})
+}
+
+// SelectQueryList gets class data by custom query into the provided slice such as *[]T
+func (c *ParseClass) SelectQueryList(query map[string]interface{}, results interface{}) error {
+	return c.SelectQuery(query, &ListResponse{Results: results})
+}
+
 // Create creates class from data
 func (c *ParseClass) Create(data interface{}, result interface{}) error {
+	data, err := c.Session.withDefaultACL(data)
+	if err != nil {
+		return err
+	}
 	return do(c.Session.post(c.ClassURL, c.UseMaster).Send(data), &result)
 }
 
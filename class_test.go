@@ -95,6 +95,81 @@ func TestParseClass(t *testing.T) {
 							})
 						})
 
+						Convey("Select object by query builder", func() {
+							type resultList struct {
+								Results []*Testdata `json:"results"`
+							}
+							var result2 resultList
+							err := testingClass.NewQuery().
+								EqualTo("key", data.Key).
+								GreaterThan("code", 200).
+								Descending("createdAt").
+								Keys("code", "name").
+								Limit(10).
+								Find(&result2)
+
+							Convey("Checking", func() {
+								So(err, ShouldBeNil)
+								So(len(result2.Results), ShouldEqual, 1)
+								So(result2.Results[0].ObjectID, ShouldEqual, result.ObjectID)
+								So(result2.Results[0].Code, ShouldEqual, data.Code)
+								So(result2.Results[0].Name, ShouldEqual, data.Name)
+								So(result2.Results[0].Key, ShouldBeEmpty)
+							})
+						})
+
+						Convey("Select object list by query", func() {
+							var results []Testdata
+							params := map[string]interface{}{
+								"key": data.Key,
+							}
+							err := testingClass.SelectQueryList(params, &results)
+
+							var results2 []Testdata
+							err2 := testingClass.NewQuery().EqualTo("key", data.Key).FindList(&results2)
+
+							Convey("Checking", func() {
+								So(err, ShouldBeNil)
+								So(len(results), ShouldEqual, 1)
+								So(results[0].ObjectID, ShouldEqual, result.ObjectID)
+								So(results[0].Name, ShouldEqual, data.Name)
+								So(err2, ShouldBeNil)
+								So(len(results2), ShouldEqual, 1)
+								So(results2[0].ObjectID, ShouldEqual, result.ObjectID)
+							})
+						})
+
+						Convey("Count object by query", func() {
+							params := map[string]interface{}{
+								"key": data.Key,
+							}
+							count, err := testingClass.Count(params)
+
+							Convey("Checking", func() {
+								So(err, ShouldBeNil)
+								So(count, ShouldEqual, 1)
+							})
+						})
+
+						Convey("Count object by query builder", func() {
+							type resultList struct {
+								Results []*Testdata `json:"results"`
+							}
+							var result2 resultList
+							q := testingClass.NewQuery().EqualTo("key", data.Key)
+							count, err := q.Count()
+							count2, err2 := q.FindWithCount(&result2)
+
+							Convey("Checking", func() {
+								So(err, ShouldBeNil)
+								So(count, ShouldEqual, 1)
+								So(err2, ShouldBeNil)
+								So(count2, ShouldEqual, 1)
+								So(len(result2.Results), ShouldEqual, 1)
+								So(result2.Results[0].ObjectID, ShouldEqual, result.ObjectID)
+							})
+						})
+
 						Convey("It is not found", func() {
 							var result2 Testdata
 							err := testingClass.Select("hoge", &result2)
@@ -145,6 +220,29 @@ func TestParseClass(t *testing.T) {
 							})
 						})
 					})
+
+					Convey("Update by operations", func() {
+						So(err, ShouldBeNil)
+
+						var result2 Testdata
+						err := testingClass.Update(result.ObjectID, map[string]interface{}{
+							"code": Increment(10),
+							"name": DeleteField(),
+						}, &result2)
+
+						Convey("Select object", func() {
+							So(err, ShouldBeNil)
+
+							var result3 Testdata
+							err := testingClass.Select(result.ObjectID, &result3)
+
+							Convey("Checking", func() {
+								So(err, ShouldBeNil)
+								So(result3.Code, ShouldEqual, data.Code+10)
+								So(result3.Name, ShouldBeEmpty)
+							})
+						})
+					})
 				})
 
 				Convey("Delete class object", func() {
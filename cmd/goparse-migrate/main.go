@@ -0,0 +1,62 @@
+// Command goparse-migrate keeps the schemas of a Parse app in sync with a desired state file.
+//
+// The file lists the classes with their fields, class level permissions and indexes in YAML or JSON:
+//
+//	classes:
+//	  - className: Post
+//	    fields:
+//	      title: {type: String, required: true}
+//	      author: {type: Pointer, targetClass: _User}
+//	    indexes:
+//	      title_author: {title: 1, author: 1}
+//
+// The client is configured by PARSE_ENDPOINT_URL, PARSE_APPLICATION_ID, PARSE_REST_API_KEY
+// and PARSE_MASTER_KEY. The plan is printed without -apply.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dogenzaka/goparse"
+)
+
+func main() {
+	file := flag.String("file", "schema.yml", "the desired state file in YAML or JSON")
+	apply := flag.Bool("apply", false, "apply the plan to the live schemas")
+	prune := flag.Bool("prune", false, "delete the fields and the indexes which are not in the file")
+	flag.Parse()
+
+	if err := run(*file, *apply, *prune, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Make the plan from the file and the live schemas, and apply it if apply is true
+func run(file string, apply bool, prune bool, w io.Writer) error {
+	state, err := LoadState(file)
+	if err != nil {
+		return err
+	}
+	client, err := goparse.NewClient()
+	if err != nil {
+		return err
+	}
+	schemas := client.NewSession("").NewSchemas()
+	live, err := schemas.All()
+	if err != nil {
+		return err
+	}
+	plan, err := MakePlan(state, live, prune)
+	if err != nil {
+		return err
+	}
+	plan.Print(w)
+	if !apply || len(plan.Steps) == 0 {
+		return nil
+	}
+	return plan.Apply(schemas, w)
+}
@@ -0,0 +1,318 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dogenzaka/goparse"
+)
+
+// defaultFields are the fields which every class has and are never deleted
+var defaultFields = map[string]bool{
+	"objectId":  true,
+	"createdAt": true,
+	"updatedAt": true,
+	"ACL":       true,
+}
+
+// defaultIndex is the index which every class has and is never dropped
+const defaultIndex = "_id_"
+
+// Step is a change to the live schemas
+type Step struct {
+	ClassName   string
+	Description string
+	apply       func(schemas *goparse.ParseSchemas) error
+}
+
+// String returns the description of the step
+func (s Step) String() string {
+	return s.Description
+}
+
+// Plan is the steps which make the live schemas the desired state
+type Plan struct {
+	Steps []Step
+}
+
+// MakePlan diffs the desired state against the live schemas.
+// If prune is true, the fields and the indexes which are not in the desired state are deleted,
+// except for the fields and the indexes of the system classes such as _User.
+// Classes which are not in the desired state are left as they are.
+func MakePlan(desired *State, live []goparse.Schema, prune bool) (*Plan, error) {
+	liveClasses := map[string]goparse.Schema{}
+	for _, schema := range live {
+		liveClasses[schema.ClassName] = schema
+	}
+
+	plan := &Plan{}
+	var conflicts []string
+	for _, want := range desired.Classes {
+		have, ok := liveClasses[want.ClassName]
+		if !ok {
+			plan.createClass(want)
+			continue
+		}
+		conflicts = append(conflicts, plan.diffFields(want, have, prune)...)
+		if err := plan.diffClassLevelPermissions(want, have); err != nil {
+			return nil, err
+		}
+		if err := plan.diffIndexes(want, have, prune); err != nil {
+			return nil, err
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, errors.New("fields can not be changed in place:\n  " + strings.Join(conflicts, "\n  "))
+	}
+	return plan, nil
+}
+
+// Print prints the steps of the plan
+func (p *Plan) Print(w io.Writer) {
+	if len(p.Steps) == 0 {
+		fmt.Fprintln(w, "No changes. The schemas are up to date.")
+		return
+	}
+	for _, step := range p.Steps {
+		fmt.Fprintln(w, step)
+	}
+	fmt.Fprintf(w, "%d changes\n", len(p.Steps))
+}
+
+// Apply applies the steps in order, and stops at the first error
+func (p *Plan) Apply(schemas *goparse.ParseSchemas, w io.Writer) error {
+	for i, step := range p.Steps {
+		if err := step.apply(schemas); err != nil {
+			return fmt.Errorf("%s: %v", step, err)
+		}
+		fmt.Fprintf(w, "[%d/%d] %s\n", i+1, len(p.Steps), step)
+	}
+	return nil
+}
+
+// Add a step
+func (p *Plan) add(className string, description string, apply func(schemas *goparse.ParseSchemas) error) {
+	p.Steps = append(p.Steps, Step{
+		ClassName:   className,
+		Description: description,
+		apply:       apply,
+	})
+}
+
+// Create the class with all the fields, the permissions and the indexes
+func (p *Plan) createClass(want goparse.Schema) {
+	p.add(want.ClassName, fmt.Sprintf("+ create class %s (%d fields, %d indexes)",
+		want.ClassName, len(want.Fields), len(want.Indexes)), func(schemas *goparse.ParseSchemas) error {
+		_, err := schemas.Create(want)
+		return err
+	})
+}
+
+// Add the missing fields, and delete the unknown fields if prune is true.
+// It returns the fields whose types or options differ, because they can not be changed in place.
+func (p *Plan) diffFields(want goparse.Schema, have goparse.Schema, prune bool) (conflicts []string) {
+	className := want.ClassName
+	for _, name := range sortedFieldNames(want.Fields) {
+		wantField := want.Fields[name]
+		haveField, ok := have.Fields[name]
+		if !ok {
+			name := name
+			p.add(className, fmt.Sprintf("+ add field %s.%s (%s)", className, name, fieldType(wantField)),
+				func(schemas *goparse.ParseSchemas) error {
+					_, err := schemas.AddFields(className, map[string]goparse.SchemaField{name: wantField})
+					return err
+				})
+			continue
+		}
+		if haveField.Type != wantField.Type || haveField.TargetClass != wantField.TargetClass {
+			conflicts = append(conflicts, fmt.Sprintf("%s.%s is %s, but %s is desired",
+				className, name, fieldType(haveField), fieldType(wantField)))
+		}
+		if haveField.Required != wantField.Required {
+			conflicts = append(conflicts, fmt.Sprintf("%s.%s has required %t, but %t is desired",
+				className, name, haveField.Required, wantField.Required))
+		}
+		if haveDefault, wantDefault := jsonString(haveField.DefaultValue), jsonString(wantField.DefaultValue); haveDefault != wantDefault {
+			conflicts = append(conflicts, fmt.Sprintf("%s.%s has defaultValue %s, but %s is desired",
+				className, name, haveDefault, wantDefault))
+		}
+	}
+
+	if !prune || isSystemClass(className) {
+		return conflicts
+	}
+	for _, name := range sortedFieldNames(have.Fields) {
+		if _, ok := want.Fields[name]; ok || defaultFields[name] {
+			continue
+		}
+		name := name
+		p.add(className, fmt.Sprintf("- delete field %s.%s", className, name),
+			func(schemas *goparse.ParseSchemas) error {
+				_, err := schemas.DeleteFields(className, name)
+				return err
+			})
+	}
+	return conflicts
+}
+
+// Update the class level permissions if the operations in the desired state differ.
+// The operations which are not in the desired state are left as they are, because the server
+// returns all the operations and the protected fields even if they have never been set.
+func (p *Plan) diffClassLevelPermissions(want goparse.Schema, have goparse.Schema) error {
+	if want.ClassLevelPermissions == nil {
+		return nil
+	}
+	wantCLP, err := permissionMap(want.ClassLevelPermissions)
+	if err != nil {
+		return err
+	}
+	merged, err := permissionMap(have.ClassLevelPermissions)
+	if err != nil {
+		return err
+	}
+	var changed []string
+	for key, value := range wantCLP {
+		if !bytes.Equal(value, merged[key]) {
+			merged[key] = value
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	sort.Strings(changed)
+
+	b, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+	var clp goparse.ClassLevelPermissions
+	if err := json.Unmarshal(b, &clp); err != nil {
+		return err
+	}
+	className := want.ClassName
+	p.add(className, fmt.Sprintf("~ update class level permissions of %s (%s)", className, strings.Join(changed, ", ")),
+		func(schemas *goparse.ParseSchemas) error {
+			_, err := schemas.UpdateClassLevelPermissions(className, clp)
+			return err
+		})
+	return nil
+}
+
+// Add the missing indexes, recreate the changed indexes, and drop the unknown indexes if prune is true
+func (p *Plan) diffIndexes(want goparse.Schema, have goparse.Schema, prune bool) error {
+	className := want.ClassName
+	for _, name := range sortedIndexNames(want.Indexes) {
+		index := want.Indexes[name]
+		if haveIndex, ok := have.Indexes[name]; ok {
+			same, err := sameJSON(index, haveIndex)
+			if err != nil {
+				return err
+			}
+			if same {
+				continue
+			}
+			p.dropIndex(className, name)
+		}
+		name := name
+		p.add(className, fmt.Sprintf("+ add index %s.%s %s", className, name, indexKeys(index)),
+			func(schemas *goparse.ParseSchemas) error {
+				_, err := schemas.AddIndex(className, name, index)
+				return err
+			})
+	}
+
+	// The indexes of the system classes such as the uniqueness of _User.username are kept
+	if !prune || isSystemClass(className) {
+		return nil
+	}
+	for _, name := range sortedIndexNames(have.Indexes) {
+		if _, ok := want.Indexes[name]; ok || name == defaultIndex {
+			continue
+		}
+		p.dropIndex(className, name)
+	}
+	return nil
+}
+
+// Drop the index
+func (p *Plan) dropIndex(className string, name string) {
+	p.add(className, fmt.Sprintf("- drop index %s.%s", className, name),
+		func(schemas *goparse.ParseSchemas) error {
+			_, err := schemas.DropIndex(className, name)
+			return err
+		})
+}
+
+// Check the class is a system class such as _User or _Role
+func isSystemClass(className string) bool {
+	return strings.HasPrefix(className, "_")
+}
+
+// Encode the class level permissions as the map of the operations
+func permissionMap(clp *goparse.ClassLevelPermissions) (map[string]json.RawMessage, error) {
+	m := map[string]json.RawMessage{}
+	if clp == nil {
+		return m, nil
+	}
+	b, err := json.Marshal(clp)
+	if err != nil {
+		return nil, err
+	}
+	return m, json.Unmarshal(b, &m)
+}
+
+// Compare the values by JSON
+func sameJSON(a interface{}, b interface{}) (bool, error) {
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(ab, bb), nil
+}
+
+// Describe the type of the field
+func fieldType(field goparse.SchemaField) string {
+	if field.TargetClass != "" {
+		return field.Type + "<" + field.TargetClass + ">"
+	}
+	return field.Type
+}
+
+// Describe the keys of the index
+func indexKeys(index goparse.Index) string {
+	return jsonString(index)
+}
+
+// Describe the value as JSON
+func jsonString(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+func sortedFieldNames(fields map[string]goparse.SchemaField) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedIndexNames(indexes map[string]goparse.Index) []string {
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dogenzaka/goparse"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func descriptions(plan *Plan) []string {
+	var result []string
+	for _, step := range plan.Steps {
+		result = append(result, step.String())
+	}
+	return result
+}
+
+// The class level permissions which the server returns for a new class
+func serverCLP() *goparse.ClassLevelPermissions {
+	return &goparse.ClassLevelPermissions{
+		Find:            &goparse.ClassPermission{Public: true},
+		Count:           &goparse.ClassPermission{Public: true},
+		Get:             &goparse.ClassPermission{Public: true},
+		Create:          &goparse.ClassPermission{Public: true},
+		Update:          &goparse.ClassPermission{Public: true},
+		Delete:          &goparse.ClassPermission{Public: true},
+		AddField:        &goparse.ClassPermission{Public: true},
+		ProtectedFields: map[string][]string{"*": {}},
+	}
+}
+
+func TestPlan(t *testing.T) {
+
+	Convey("Given the desired state and the live schemas", t, func() {
+
+		desired := &State{Classes: []goparse.Schema{
+			{
+				ClassName: "Post",
+				Fields: map[string]goparse.SchemaField{
+					"title":  {Type: goparse.FieldTypeString},
+					"author": {Type: goparse.FieldTypePointer, TargetClass: "_User"},
+				},
+				ClassLevelPermissions: &goparse.ClassLevelPermissions{
+					Find: &goparse.ClassPermission{Public: true},
+				},
+				Indexes: map[string]goparse.Index{
+					"title_1":  {{Field: "title", Value: 1}},
+					"author_1": {{Field: "author", Value: 1}},
+				},
+			},
+			{
+				ClassName: "Comment",
+				Fields: map[string]goparse.SchemaField{
+					"body": {Type: goparse.FieldTypeString},
+				},
+			},
+			{
+				ClassName: "_User",
+				Fields: map[string]goparse.SchemaField{
+					"nickname": {Type: goparse.FieldTypeString},
+				},
+			},
+		}}
+
+		live := []goparse.Schema{
+			{
+				ClassName: "Post",
+				Fields: map[string]goparse.SchemaField{
+					"objectId": {Type: goparse.FieldTypeString},
+					"ACL":      {Type: goparse.FieldTypeACL},
+					"title":    {Type: goparse.FieldTypeString},
+					"draft":    {Type: goparse.FieldTypeBoolean},
+				},
+				ClassLevelPermissions: serverCLP(),
+				Indexes: map[string]goparse.Index{
+					"_id_":    {{Field: "_id", Value: int64(1)}},
+					"title_1": {{Field: "title", Value: int64(-1)}},
+					"draft_1": {{Field: "draft", Value: int64(1)}},
+				},
+			},
+			{
+				ClassName: "_User",
+				Fields: map[string]goparse.SchemaField{
+					"username": {Type: goparse.FieldTypeString},
+					"email":    {Type: goparse.FieldTypeString},
+				},
+				Indexes: map[string]goparse.Index{
+					"_id_":       {{Field: "_id", Value: int64(1)}},
+					"username_1": {{Field: "username", Value: int64(1)}},
+					"email_1":    {{Field: "email", Value: int64(1)}},
+				},
+			},
+		}
+
+		Convey("When making a plan", func() {
+
+			plan, err := MakePlan(desired, live, false)
+
+			Convey("It adds the missing classes, fields and indexes", func() {
+				So(err, ShouldBeNil)
+				So(descriptions(plan), ShouldResemble, []string{
+					"+ add field Post.author (Pointer<_User>)",
+					"+ add index Post.author_1 {\"author\":1}",
+					"- drop index Post.title_1",
+					"+ add index Post.title_1 {\"title\":1}",
+					"+ create class Comment (1 fields, 0 indexes)",
+					"+ add field _User.nickname (String)",
+				})
+			})
+		})
+
+		Convey("When making a plan with prune", func() {
+
+			plan, err := MakePlan(desired, live, true)
+
+			Convey("It also deletes the unknown fields and indexes except the defaults", func() {
+				So(err, ShouldBeNil)
+				So(descriptions(plan), ShouldContain, "- delete field Post.draft")
+				So(descriptions(plan), ShouldContain, "- drop index Post.draft_1")
+				So(descriptions(plan), ShouldNotContain, "- delete field Post.ACL")
+				So(descriptions(plan), ShouldNotContain, "- drop index Post._id_")
+				So(descriptions(plan), ShouldNotContain, "- delete field _User.username")
+			})
+
+			Convey("It keeps the indexes of the system classes", func() {
+				So(err, ShouldBeNil)
+				So(descriptions(plan), ShouldNotContain, "- drop index _User.username_1")
+				So(descriptions(plan), ShouldNotContain, "- drop index _User.email_1")
+			})
+		})
+
+		Convey("When the class level permissions in the desired state are the same as the live", func() {
+
+			plan, err := MakePlan(desired, live, false)
+
+			Convey("It ignores the operations which are not in the desired state", func() {
+				So(err, ShouldBeNil)
+				for _, step := range plan.Steps {
+					So(step.Description, ShouldNotStartWith, "~ update class level permissions")
+				}
+			})
+		})
+
+		Convey("When the class level permissions differ", func() {
+
+			live[0].ClassLevelPermissions.Find = &goparse.ClassPermission{RequiresAuthentication: true}
+			plan, err := MakePlan(desired, live, false)
+
+			Convey("It updates the differing operations", func() {
+				So(err, ShouldBeNil)
+				So(descriptions(plan), ShouldContain, "~ update class level permissions of Post (find)")
+			})
+		})
+
+		Convey("When a field type differs", func() {
+
+			live[0].Fields["title"] = goparse.SchemaField{Type: goparse.FieldTypeNumber}
+			_, err := MakePlan(desired, live, false)
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "Post.title is Number, but String is desired")
+			})
+		})
+
+		Convey("When the options of a field differ", func() {
+
+			live[0].Fields["title"] = goparse.SchemaField{Type: goparse.FieldTypeString, Required: true, DefaultValue: "untitled"}
+			_, err := MakePlan(desired, live, false)
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "Post.title has required true, but false is desired")
+				So(err.Error(), ShouldContainSubstring, `Post.title has defaultValue "untitled", but null is desired`)
+			})
+		})
+
+		Convey("When the live schemas are up to date", func() {
+
+			plan, err := MakePlan(&State{Classes: live}, live, true)
+
+			Convey("It has no steps", func() {
+				So(err, ShouldBeNil)
+				So(plan.Steps, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a plan", t, func() {
+
+		var requests []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			requests = append(requests, r.Method+" "+r.URL.Path+" "+string(b))
+			w.Write([]byte(`{"className":"Post"}`))
+		}))
+		defer server.Close()
+
+		client, err := goparse.NewClientWithConfig(goparse.ParseConfig{
+			ApplicationID: "APPID",
+			MasterKey:     "MASTERKEY",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		desired := &State{Classes: []goparse.Schema{{
+			ClassName: "Post",
+			Indexes: map[string]goparse.Index{
+				"title_author": {{Field: "title", Value: 1}, {Field: "author", Value: -1}},
+			},
+		}}}
+		plan, err := MakePlan(desired, []goparse.Schema{{ClassName: "Post"}}, false)
+		So(err, ShouldBeNil)
+
+		Convey("When applying the plan", func() {
+
+			var out bytes.Buffer
+			err := plan.Apply(client.NewSession("").NewSchemas(), &out)
+
+			Convey("It sends the steps in order", func() {
+				So(err, ShouldBeNil)
+				So(requests, ShouldResemble, []string{
+					`PUT /schemas/Post {"className":"Post","indexes":{"title_author":{"title":1,"author":-1}}}`,
+				})
+				So(out.String(), ShouldEqual, "[1/1] + add index Post.title_author {\"title\":1,\"author\":-1}\n")
+			})
+		})
+
+		Convey("When applying the class level permissions", func() {
+
+			live := serverCLP()
+			live.Delete = &goparse.ClassPermission{Roles: []string{"admin"}}
+			desired := &State{Classes: []goparse.Schema{{
+				ClassName: "Post",
+				ClassLevelPermissions: &goparse.ClassLevelPermissions{
+					Find: &goparse.ClassPermission{RequiresAuthentication: true},
+				},
+			}}}
+			plan, err := MakePlan(desired, []goparse.Schema{{ClassName: "Post", ClassLevelPermissions: live}}, false)
+			So(err, ShouldBeNil)
+
+			var out bytes.Buffer
+			err = plan.Apply(client.NewSession("").NewSchemas(), &out)
+
+			Convey("It keeps the operations which are not in the desired state", func() {
+				So(err, ShouldBeNil)
+				So(requests, ShouldResemble, []string{
+					`PUT /schemas/Post {"classLevelPermissions":{` +
+						`"find":{"requiresAuthentication":true},` +
+						`"count":{"*":true},` +
+						`"get":{"*":true},` +
+						`"create":{"*":true},` +
+						`"update":{"*":true},` +
+						`"delete":{"role:admin":true},` +
+						`"addField":{"*":true},` +
+						`"protectedFields":{"*":[]}},` +
+						`"className":"Post"}`,
+				})
+			})
+		})
+	})
+}
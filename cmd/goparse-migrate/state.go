@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/dogenzaka/goparse"
+	"gopkg.in/yaml.v2"
+)
+
+// State is the desired state of the classes
+type State struct {
+	Classes []goparse.Schema `json:"classes"`
+}
+
+// LoadState loads the desired state from a YAML or JSON file
+func LoadState(path string) (*State, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		return ParseYAMLState(b)
+	default:
+		return ParseJSONState(b)
+	}
+}
+
+// ParseJSONState parses the desired state from JSON
+func ParseJSONState(b []byte) (*State, error) {
+	var state State
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, err
+	}
+	if err := state.validate(); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// ParseYAMLState parses the desired state from YAML.
+// The YAML is converted into JSON keeping the order of the keys, which matters for the indexes.
+func ParseYAMLState(b []byte) (*State, error) {
+	var v yaml.MapSlice
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, v); err != nil {
+		return nil, err
+	}
+	return ParseJSONState(buf.Bytes())
+}
+
+// Check that the classes are named and not duplicated
+func (s *State) validate() error {
+	names := map[string]bool{}
+	for i, class := range s.Classes {
+		if class.ClassName == "" {
+			return fmt.Errorf("classes[%d] has no className", i)
+		}
+		if names[class.ClassName] {
+			return fmt.Errorf("class %s is defined twice", class.ClassName)
+		}
+		names[class.ClassName] = true
+	}
+	return nil
+}
+
+// Write a decoded YAML value as JSON
+func writeJSON(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case yaml.MapSlice:
+		buf.WriteByte('{')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := json.Marshal(fmt.Sprint(item.Key))
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, item.Value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/dogenzaka/goparse"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestState(t *testing.T) {
+
+	Convey("Given a YAML state", t, func() {
+
+		s := `
+classes:
+  - className: Post
+    fields:
+      title: {type: String, required: true}
+      author: {type: Pointer, targetClass: _User}
+    classLevelPermissions:
+      find: {"*": true}
+      delete: {"role:admin": true}
+    indexes:
+      title_author: {title: 1, author: -1}
+      title_text: {title: text}
+`
+		state, err := ParseYAMLState([]byte(s))
+
+		Convey("It has the classes", func() {
+			So(err, ShouldBeNil)
+			So(len(state.Classes), ShouldEqual, 1)
+			post := state.Classes[0]
+			So(post.ClassName, ShouldEqual, "Post")
+			So(post.Fields["title"], ShouldResemble, goparse.SchemaField{Type: goparse.FieldTypeString, Required: true})
+			So(post.Fields["author"], ShouldResemble, goparse.SchemaField{Type: goparse.FieldTypePointer, TargetClass: "_User"})
+			So(*post.ClassLevelPermissions.Find, ShouldResemble, goparse.ClassPermission{Public: true})
+			So(*post.ClassLevelPermissions.Delete, ShouldResemble, goparse.ClassPermission{Roles: []string{"admin"}})
+		})
+
+		Convey("It keeps the order of the index keys", func() {
+			So(err, ShouldBeNil)
+			indexes := state.Classes[0].Indexes
+			So(indexes["title_author"], ShouldResemble, goparse.Index{{Field: "title", Value: int64(1)}, {Field: "author", Value: int64(-1)}})
+			So(indexes["title_text"], ShouldResemble, goparse.Index{{Field: "title", Value: "text"}})
+		})
+	})
+
+	Convey("Given a JSON state", t, func() {
+
+		Convey("When a class has no className", func() {
+
+			_, err := ParseJSONState([]byte(`{"classes":[{"fields":{}}]}`))
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+
+		Convey("When a class is defined twice", func() {
+
+			_, err := ParseJSONState([]byte(`{"classes":[{"className":"Post"},{"className":"Post"}]}`))
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "class Post is defined twice")
+			})
+		})
+	})
+}
@@ -0,0 +1,168 @@
+package goparse
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+const pathConfig = "/config"
+
+// String gets the param as a string. It returns "" if the param is not a string.
+func (c Config) String(key string) string {
+	v, _ := c.Params[key].(string)
+	return v
+}
+
+// Bool gets the param as a bool. It returns false if the param is not a bool.
+func (c Config) Bool(key string) bool {
+	v, _ := c.Params[key].(bool)
+	return v
+}
+
+// Float64 gets the param as a number. It returns 0 if the param is not a number.
+func (c Config) Float64(key string) float64 {
+	v, _ := c.Params[key].(float64)
+	return v
+}
+
+// Decode decodes the params into the result such as a struct with JSON tags
+func (c Config) Decode(result interface{}) error {
+	b, err := json.Marshal(c.Params)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, result)
+}
+
+// Copy the config deeply so that the changes by the caller do not affect the cache
+func (c Config) copy() Config {
+	config := Config{}
+	if c.Params != nil {
+		config.Params = copyValue(c.Params).(map[string]interface{})
+	}
+	if c.MasterKeyOnly != nil {
+		config.MasterKeyOnly = make(map[string]bool, len(c.MasterKeyOnly))
+		for key, value := range c.MasterKeyOnly {
+			config.MasterKeyOnly[key] = value
+		}
+	}
+	return config
+}
+
+// Copy the value decoded from JSON deeply
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[key] = copyValue(value)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(v))
+		for i, value := range v {
+			a[i] = copyValue(value)
+		}
+		return a
+	default:
+		return v
+	}
+}
+
+// GetConfig gets the params of Parse Config
+func (s *ParseSession) GetConfig() (Config, error) {
+	return s.getConfig(false)
+}
+
+// GetConfigByMaster gets the params of Parse Config including the master key only params by use master key
+func (s *ParseSession) GetConfigByMaster() (Config, error) {
+	return s.getConfig(true)
+}
+
+// Get the config by private
+func (s *ParseSession) getConfig(useMaster bool) (config Config, err error) {
+	if useMaster && s.client.MasterKey == "" {
+		return config, errors.New("request requires MasterKey")
+	}
+	return config, do(s.get(pathConfig, useMaster), &config)
+}
+
+// UpdateConfigByMaster updates the params of Parse Config by use master key.
+// The params which are not given are left as they are, and a param is deleted by DeleteField().
+// masterKeyOnly sets whether the params are got only by use master key, and it can be nil.
+func (s *ParseSession) UpdateConfigByMaster(params map[string]interface{}, masterKeyOnly map[string]bool) error {
+	if len(params) == 0 {
+		return errors.New("params must not be empty")
+	}
+	if s.client.MasterKey == "" {
+		return errors.New("request requires MasterKey")
+	}
+	body := map[string]interface{}{
+		"params": params,
+	}
+	if len(masterKeyOnly) > 0 {
+		body["masterKeyOnly"] = masterKeyOnly
+	}
+	req, err := sendJSON(s.put(pathConfig, true), body)
+	if err != nil {
+		return err
+	}
+	return do(req, nil)
+}
+
+// ConfigCache caches Parse Config in process, and fetches it again when the refresh interval has passed.
+// The config is never fetched again if RefreshInterval is not positive. Set UseMaster to get the
+// master key only params. It is safe for concurrent use, and each caller gets its own copy of the config.
+type ConfigCache struct {
+	session         *ParseSession
+	UseMaster       bool
+	RefreshInterval time.Duration
+
+	mu        sync.Mutex
+	config    *Config
+	fetchedAt time.Time
+	now       func() time.Time
+}
+
+// NewConfigCache creates a config cache from the session
+func (s *ParseSession) NewConfigCache(refreshInterval time.Duration) *ConfigCache {
+	return &ConfigCache{
+		session:         s,
+		RefreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+}
+
+// Get gets the cached config, and fetches it if it is not cached or the refresh interval has passed.
+// If the fetch fails, the stale config is returned with the error.
+func (c *ConfigCache) Get() (Config, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.config != nil && (c.RefreshInterval <= 0 || c.now().Sub(c.fetchedAt) < c.RefreshInterval) {
+		return c.config.copy(), nil
+	}
+	return c.fetch()
+}
+
+// Refresh fetches the config regardless of the refresh interval
+func (c *ConfigCache) Refresh() (Config, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fetch()
+}
+
+// Fetch the config and cache it
+func (c *ConfigCache) fetch() (Config, error) {
+	config, err := c.session.getConfig(c.UseMaster)
+	if err != nil {
+		if c.config != nil {
+			return c.config.copy(), err
+		}
+		return config, err
+	}
+	c.config = &config
+	c.fetchedAt = c.now()
+	return config.copy(), nil
+}
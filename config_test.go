@@ -0,0 +1,199 @@
+package goparse
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfig(t *testing.T) {
+
+	Convey("Given a session", t, func() {
+
+		var method, rawBody string
+		var header http.Header
+		fetches := 0
+		failing := false
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			header = r.Header
+			b, _ := ioutil.ReadAll(r.Body)
+			rawBody = string(b)
+
+			switch {
+			case failing:
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"code":1,"error":"internal error"}`))
+			case r.Method == "GET" && r.Header.Get(headerMasterKey) != "":
+				fetches++
+				w.Write([]byte(`{"params":{"welcome":"Hello","newFeature":true,"maxItems":20,"secret":"s3cr3t"},"masterKeyOnly":{"secret":true}}`))
+			case r.Method == "GET":
+				fetches++
+				w.Write([]byte(`{"params":{"welcome":"Hello","newFeature":true,"maxItems":20}}`))
+			case r.Method == "PUT":
+				w.Write([]byte(`{"result":true}`))
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			MasterKey:     "MASTERKEY",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		session := client.NewSession("")
+
+		Convey("When getting the config", func() {
+
+			config, err := session.GetConfig()
+
+			Convey("It returns the params without the master key", func() {
+				So(err, ShouldBeNil)
+				So(header.Get(headerMasterKey), ShouldBeEmpty)
+				So(config.String("welcome"), ShouldEqual, "Hello")
+				So(config.Bool("newFeature"), ShouldBeTrue)
+				So(config.Float64("maxItems"), ShouldEqual, 20)
+				So(config.String("secret"), ShouldBeEmpty)
+				So(config.MasterKeyOnly, ShouldBeNil)
+			})
+
+			Convey("It decodes the params into a struct", func() {
+				var params struct {
+					Welcome    string `json:"welcome"`
+					NewFeature bool   `json:"newFeature"`
+					MaxItems   int    `json:"maxItems"`
+				}
+				So(config.Decode(&params), ShouldBeNil)
+				So(params.Welcome, ShouldEqual, "Hello")
+				So(params.NewFeature, ShouldBeTrue)
+				So(params.MaxItems, ShouldEqual, 20)
+			})
+		})
+
+		Convey("When getting the config by master", func() {
+
+			config, err := session.GetConfigByMaster()
+
+			Convey("It returns the master key only params", func() {
+				So(err, ShouldBeNil)
+				So(header.Get(headerMasterKey), ShouldEqual, "MASTERKEY")
+				So(config.String("secret"), ShouldEqual, "s3cr3t")
+				So(config.MasterKeyOnly, ShouldResemble, map[string]bool{"secret": true})
+			})
+		})
+
+		Convey("When updating the config", func() {
+
+			err := session.UpdateConfigByMaster(map[string]interface{}{
+				"welcome": "Hi",
+				"old":     DeleteField(),
+			}, map[string]bool{"welcome": false})
+
+			Convey("It puts the params with the master key", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "PUT")
+				So(header.Get(headerMasterKey), ShouldEqual, "MASTERKEY")
+				So(rawBody, ShouldEqual, `{"masterKeyOnly":{"welcome":false},"params":{"old":{"__op":"Delete"},"welcome":"Hi"}}`)
+			})
+		})
+
+		Convey("When updating the config without the master key", func() {
+
+			client.MasterKey = ""
+			err := session.UpdateConfigByMaster(map[string]interface{}{"welcome": "Hi"}, nil)
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "request requires MasterKey")
+				So(method, ShouldBeEmpty)
+			})
+		})
+
+		Convey("Given a config cache", func() {
+
+			now := time.Date(2016, 10, 12, 8, 31, 59, 0, time.UTC)
+			cache := session.NewConfigCache(time.Minute)
+			cache.now = func() time.Time { return now }
+
+			config, err := cache.Get()
+			So(err, ShouldBeNil)
+			So(config.String("welcome"), ShouldEqual, "Hello")
+			So(fetches, ShouldEqual, 1)
+
+			Convey("When getting the config within the refresh interval", func() {
+
+				now = now.Add(30 * time.Second)
+				_, err := cache.Get()
+
+				Convey("It returns the cached config", func() {
+					So(err, ShouldBeNil)
+					So(fetches, ShouldEqual, 1)
+				})
+			})
+
+			Convey("When getting the config after the refresh interval", func() {
+
+				now = now.Add(time.Minute)
+				_, err := cache.Get()
+
+				Convey("It fetches the config again", func() {
+					So(err, ShouldBeNil)
+					So(fetches, ShouldEqual, 2)
+				})
+			})
+
+			Convey("When refreshing the config", func() {
+
+				_, err := cache.Refresh()
+
+				Convey("It fetches the config regardless of the interval", func() {
+					So(err, ShouldBeNil)
+					So(fetches, ShouldEqual, 2)
+				})
+			})
+
+			Convey("When the caller changes the config", func() {
+
+				config.Params["welcome"] = "Changed"
+				config.MasterKeyOnly = nil
+				cached, err := cache.Get()
+
+				Convey("It does not change the cache", func() {
+					So(err, ShouldBeNil)
+					So(cached.String("welcome"), ShouldEqual, "Hello")
+					So(fetches, ShouldEqual, 1)
+				})
+			})
+
+			Convey("When the fetch fails", func() {
+
+				failing = true
+				now = now.Add(time.Minute)
+				config, err := cache.Get()
+
+				Convey("It returns the stale config with the error", func() {
+					So(err, ShouldNotBeNil)
+					So(config.String("welcome"), ShouldEqual, "Hello")
+				})
+			})
+
+			Convey("When using the master key", func() {
+
+				cache.UseMaster = true
+				config, err := cache.Refresh()
+
+				Convey("It returns the master key only params", func() {
+					So(err, ShouldBeNil)
+					So(config.String("secret"), ShouldEqual, "s3cr3t")
+				})
+			})
+		})
+	})
+}
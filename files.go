@@ -0,0 +1,100 @@
+package goparse
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+// UploadFile uploads the content of the reader as a file.
+// The content is streamed to the server without buffering.
+// The returned file has the unique name given by the server, which can be set to a field of an object.
+func (s *ParseSession) UploadFile(name string, contentType string, r io.Reader) (file File, err error) {
+	if name == "" {
+		return file, errors.New("name must not be empty")
+	}
+	if contentType == "" {
+		return file, errors.New("contentType must not be empty")
+	}
+	req, err := streamRequest(s.post("/files/"+url.PathEscape(name), false), r)
+	if err != nil {
+		return file, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return file, s.doStream(req, &file)
+}
+
+// DownloadFile writes the content of the file to the writer, and returns the number of bytes written.
+// The file is got from its URL without the keys, because the URL may point to another host.
+func (s *ParseSession) DownloadFile(file File, w io.Writer) (int64, error) {
+	if file.URL == "" {
+		return 0, errors.New("file URL must not be empty")
+	}
+	res, err := s.streamClient().Get(file.URL)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return 0, fmt.Errorf("failed to download %s: %s", file.Name, res.Status)
+	}
+	return io.Copy(w, res.Body)
+}
+
+// DeleteFileByMaster deletes the file by use master key
+func (s *ParseSession) DeleteFileByMaster(name string) error {
+	if name == "" {
+		return errors.New("name must not be empty")
+	}
+	if s.client.MasterKey == "" {
+		return errors.New("request requires MasterKey")
+	}
+	return do(s.del("/files/"+url.PathEscape(name), true), nil)
+}
+
+// Create a request which has the method, URL and headers of the agent, and streams the body
+func streamRequest(agent *gorequest.SuperAgent, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(agent.Method, agent.Url, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range agent.Header {
+		req.Header.Set(key, value)
+	}
+	return req, nil
+}
+
+// Create a client for streaming. The timeout limits the connection and the wait for the response headers,
+// but not the transfer of the body which may take long for large files.
+func (s *ParseSession) streamClient() *http.Client {
+	timeout := s.client.TimeOut
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           (&net.Dialer{Timeout: timeout}).DialContext,
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+			DisableKeepAlives:     true,
+		},
+	}
+}
+
+// Execute a streaming request
+func (s *ParseSession) doStream(req *http.Request, data interface{}) error {
+	res, err := s.streamClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(res.StatusCode, string(body), data)
+}
@@ -0,0 +1,232 @@
+package goparse
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFiles(t *testing.T) {
+
+	Convey("Given a session", t, func() {
+
+		var method, path, rawBody string
+		var header http.Header
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			path = r.URL.Path
+			header = r.Header
+			b, _ := ioutil.ReadAll(r.Body)
+			rawBody = string(b)
+
+			switch {
+			case r.Method == "POST":
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"name":"abc_hello.txt","url":"http://` + r.Host + `/files/APPID/abc_hello.txt"}`))
+			case r.Method == "GET" && r.URL.Path == "/files/APPID/abc_hello.txt":
+				w.Write([]byte("Hello, World!"))
+			case r.Method == "GET":
+				w.WriteHeader(http.StatusNotFound)
+			case r.Method == "DELETE" && r.URL.Path == "/files/missing.txt":
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":153,"error":"Could not delete file."}`))
+			case r.Method == "DELETE":
+				w.Write([]byte(`{}`))
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			MasterKey:     "MASTERKEY",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		session := client.NewSession("r:abc")
+
+		Convey("When uploading a file", func() {
+
+			file, err := session.UploadFile("hello.txt", "text/plain", strings.NewReader("Hello, World!"))
+
+			Convey("It posts the content with the content type", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "POST")
+				So(path, ShouldEqual, "/files/hello.txt")
+				So(rawBody, ShouldEqual, "Hello, World!")
+				So(header.Get("Content-Type"), ShouldEqual, "text/plain")
+				So(header.Get(headerAppID), ShouldEqual, "APPID")
+				So(header.Get(headerSessionToken), ShouldEqual, "r:abc")
+				So(header.Get(headerMasterKey), ShouldBeEmpty)
+			})
+
+			Convey("It returns the file named by the server", func() {
+				So(err, ShouldBeNil)
+				So(file.Name, ShouldEqual, "abc_hello.txt")
+				So(file.URL, ShouldEqual, server.URL+"/files/APPID/abc_hello.txt")
+			})
+
+			Convey("When downloading the file", func() {
+
+				var buf bytes.Buffer
+				n, err := session.DownloadFile(file, &buf)
+
+				Convey("It writes the content", func() {
+					So(err, ShouldBeNil)
+					So(n, ShouldEqual, 13)
+					So(buf.String(), ShouldEqual, "Hello, World!")
+					So(header.Get(headerAppID), ShouldBeEmpty)
+				})
+			})
+		})
+
+		Convey("When uploading a file without a name", func() {
+
+			_, err := session.UploadFile("", "text/plain", strings.NewReader("Hello, World!"))
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(method, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When downloading a missing file", func() {
+
+			var buf bytes.Buffer
+			_, err := session.DownloadFile(File{Name: "missing.txt", URL: server.URL + "/files/APPID/missing.txt"}, &buf)
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(buf.Len(), ShouldEqual, 0)
+			})
+		})
+
+		Convey("When deleting a file", func() {
+
+			err := session.DeleteFileByMaster("abc_hello.txt")
+
+			Convey("It deletes the file with the master key", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "DELETE")
+				So(path, ShouldEqual, "/files/abc_hello.txt")
+				So(header.Get(headerMasterKey), ShouldEqual, "MASTERKEY")
+			})
+		})
+
+		Convey("When the server fails to delete a file", func() {
+
+			err := session.DeleteFileByMaster("missing.txt")
+
+			Convey("It returns the parse error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*Error).Code, ShouldEqual, 153)
+			})
+		})
+
+		Convey("When deleting a file without the master key", func() {
+
+			client.MasterKey = ""
+			err := session.DeleteFileByMaster("abc_hello.txt")
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "request requires MasterKey")
+				So(method, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given a transfer which takes longer than the timeout", t, func() {
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case "POST":
+				b, _ := ioutil.ReadAll(r.Body)
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"name":"abc_` + string(b) + `"}`))
+			case "GET":
+				w.Write([]byte("Hello, "))
+				w.(http.Flusher).Flush()
+				time.Sleep(100 * time.Millisecond)
+				w.Write([]byte("World!"))
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			URL:           server.URL,
+			TimeOut:       50 * time.Millisecond,
+		})
+		So(err, ShouldBeNil)
+
+		session := client.NewSession("")
+
+		Convey("When uploading a file slowly", func() {
+
+			file, err := session.UploadFile("slow.txt", "text/plain", &slowReader{content: []byte("slow"), delay: 40 * time.Millisecond})
+
+			Convey("It does not time out while sending the body", func() {
+				So(err, ShouldBeNil)
+				So(file.Name, ShouldEqual, "abc_slow")
+			})
+		})
+
+		Convey("When downloading a file slowly", func() {
+
+			var buf bytes.Buffer
+			_, err := session.DownloadFile(File{Name: "slow.txt", URL: server.URL + "/slow.txt"}, &buf)
+
+			Convey("It does not time out while receiving the body", func() {
+				So(err, ShouldBeNil)
+				So(buf.String(), ShouldEqual, "Hello, World!")
+			})
+		})
+	})
+
+	Convey("Given a File", t, func() {
+
+		b, err := json.Marshal(File{Name: "abc_hello.txt"})
+		So(err, ShouldBeNil)
+
+		Convey("It has a __type and name", func() {
+			So(string(b), ShouldEqual, `{"__type":"File","name":"abc_hello.txt"}`)
+		})
+
+		Convey("When decoding a file field", func() {
+
+			var file File
+			err := json.Unmarshal([]byte(`{"__type":"File","name":"abc_hello.txt","url":"http://example.com/abc_hello.txt"}`), &file)
+
+			Convey("It has the name and URL", func() {
+				So(err, ShouldBeNil)
+				So(file, ShouldResemble, File{Name: "abc_hello.txt", URL: "http://example.com/abc_hello.txt"})
+			})
+		})
+	})
+}
+
+// slowReader reads a byte at a time after the delay
+type slowReader struct {
+	content []byte
+	delay   time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	if len(r.content) == 0 {
+		return 0, io.EOF
+	}
+	time.Sleep(r.delay)
+	n := copy(p[:1], r.content)
+	r.content = r.content[n:]
+	return n, nil
+}
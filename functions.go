@@ -0,0 +1,25 @@
+package goparse
+
+import "errors"
+
+// functionResponse is the envelope of the result of a function.
+// Set a pointer to Result to decode the result into it.
+type functionResponse struct {
+	Result interface{} `json:"result"`
+}
+
+// CallFunction calls the Cloud Code function with the params as the session user,
+// and decodes the result into result. The error thrown by the function is returned as *Error.
+func (s *ParseSession) CallFunction(name string, params interface{}, result interface{}) error {
+	if name == "" {
+		return errors.New("name must not be empty")
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	req, err := sendJSON(s.post("/functions/"+name, false), params)
+	if err != nil {
+		return err
+	}
+	return do(req, &functionResponse{Result: result})
+}
@@ -0,0 +1,86 @@
+package goparse
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCallFunction(t *testing.T) {
+
+	Convey("Given a session", t, func() {
+
+		var path, rawBody string
+		var header http.Header
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			header = r.Header
+			b, _ := ioutil.ReadAll(r.Body)
+			rawBody = string(b)
+
+			switch r.URL.Path {
+			case "/functions/hello":
+				w.Write([]byte(`{"result":{"message":"Hello, goparse!","count":2}}`))
+			case "/functions/fail":
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":141,"error":"something went wrong"}`))
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		session := client.NewSession("r:abc")
+
+		Convey("When calling a function", func() {
+
+			var result struct {
+				Message string `json:"message"`
+				Count   int    `json:"count"`
+			}
+			err := session.CallFunction("hello", map[string]string{"name": "goparse"}, &result)
+
+			Convey("It posts the params with the session token", func() {
+				So(err, ShouldBeNil)
+				So(path, ShouldEqual, "/functions/hello")
+				So(rawBody, ShouldEqual, `{"name":"goparse"}`)
+				So(header.Get(headerSessionToken), ShouldEqual, "r:abc")
+			})
+
+			Convey("It unwraps the result", func() {
+				So(err, ShouldBeNil)
+				So(result.Message, ShouldEqual, "Hello, goparse!")
+				So(result.Count, ShouldEqual, 2)
+			})
+		})
+
+		Convey("When calling a function without params and result", func() {
+
+			err := session.CallFunction("hello", nil, nil)
+
+			Convey("It posts an empty object", func() {
+				So(err, ShouldBeNil)
+				So(rawBody, ShouldEqual, `{}`)
+			})
+		})
+
+		Convey("When the function fails", func() {
+
+			err := session.CallFunction("fail", nil, nil)
+
+			Convey("It returns the error of the function", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*Error).Code, ShouldEqual, 141)
+				So(err.(*Error).Message, ShouldEqual, "something went wrong")
+			})
+		})
+	})
+}
@@ -0,0 +1,139 @@
+package goparse
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+const defaultPageSize = 100
+
+// Iterator walks through all class data which matches the query page by page.
+// Pages are fetched in ascending order of objectId instead of using skip,
+// so the order, limit and skip options of the query are ignored.
+type Iterator struct {
+	query    *Query
+	pageSize int
+	lastID   string
+	page     []json.RawMessage
+	pos      int
+	done     bool
+	err      error
+}
+
+// Iterator creates an iterator of the query. If pageSize is not positive,
+// the default page size is used.
+func (q *Query) Iterator(pageSize int) *Iterator {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return &Iterator{
+		query:    q,
+		pageSize: pageSize,
+	}
+}
+
+// Iterator creates an iterator of all class data
+func (c *ParseClass) Iterator(pageSize int) *Iterator {
+	return c.NewQuery().Iterator(pageSize)
+}
+
+// Next decodes the next class data into result, which is reset to the zero value before decoding
+// so that no fields are left from the previous data.
+// It returns false when there is no more data or an error occurred.
+func (it *Iterator) Next(result interface{}) bool {
+	if it.err != nil {
+		return false
+	}
+	if it.pos >= len(it.page) {
+		if it.done {
+			return false
+		}
+		if it.err = it.fetch(); it.err != nil {
+			return false
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+	raw := it.page[it.pos]
+	it.pos++
+	if v := reflect.ValueOf(result); v.Kind() == reflect.Ptr && !v.IsNil() {
+		v.Elem().Set(reflect.Zero(v.Elem().Type()))
+	}
+	if it.err = json.Unmarshal(raw, result); it.err != nil {
+		return false
+	}
+	return true
+}
+
+// Err returns the error occurred while iterating
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration
+func (it *Iterator) Close() error {
+	it.done = true
+	it.page = nil
+	it.pos = 0
+	return nil
+}
+
+// Fetch the next page after the last objectId
+func (it *Iterator) fetch() error {
+	vals, err := it.query.Values()
+	if err != nil {
+		return err
+	}
+
+	where := map[string]interface{}{}
+	for key, value := range it.query.where {
+		where[key] = value
+	}
+	if it.lastID != "" {
+		cond := map[string]interface{}{}
+		switch v := where["objectId"].(type) {
+		case nil:
+		case map[string]interface{}:
+			for op, value := range v {
+				cond[op] = value
+			}
+		default:
+			cond["$eq"] = v
+		}
+		cond["$gt"] = it.lastID
+		where["objectId"] = cond
+	}
+	if len(where) > 0 {
+		b, err := json.Marshal(where)
+		if err != nil {
+			return err
+		}
+		vals.Set("where", string(b))
+	}
+	vals.Set("order", "objectId")
+	vals.Set("limit", strconv.Itoa(it.pageSize))
+	vals.Del("skip")
+
+	var page []json.RawMessage
+	if err := it.query.class.selectValues(vals, &ListResponse{Results: &page}); err != nil {
+		return err
+	}
+
+	it.page = page
+	it.pos = 0
+	if len(page) < it.pageSize {
+		it.done = true
+	}
+	if len(page) > 0 {
+		var last struct {
+			ObjectID string `json:"objectId"`
+		}
+		if err := json.Unmarshal(page[len(page)-1], &last); err != nil {
+			return err
+		}
+		it.lastID = last.ObjectID
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package goparse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIterator(t *testing.T) {
+
+	Convey("Given a class which has 5 objects", t, func() {
+
+		var requests []map[string]string
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			requests = append(requests, map[string]string{
+				"where": q.Get("where"),
+				"order": q.Get("order"),
+				"limit": q.Get("limit"),
+				"skip":  q.Get("skip"),
+			})
+
+			var where struct {
+				ObjectID struct {
+					GT string `json:"$gt"`
+				} `json:"objectId"`
+			}
+			json.Unmarshal([]byte(q.Get("where")), &where)
+			limit, _ := strconv.Atoi(q.Get("limit"))
+
+			results := []map[string]interface{}{}
+			for i := 1; i <= 5 && len(results) < limit; i++ {
+				id := fmt.Sprintf("obj%d", i)
+				if id > where.ObjectID.GT {
+					result := map[string]interface{}{
+						"objectId": id,
+						"code":     i,
+					}
+					if i == 1 {
+						result["tag"] = "first"
+					}
+					results = append(results, result)
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"results": results,
+			})
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		testingClass := client.NewSession("").NewClass("Testdata")
+
+		type Testdata struct {
+			ObjectID string `json:"objectId"`
+			Code     int    `json:"code"`
+		}
+
+		Convey("When iterating with page size 2", func() {
+
+			it := testingClass.NewQuery().
+				GreaterThan("code", 0).
+				Descending("code").
+				Skip(10).
+				Iterator(2)
+
+			var codes []int
+			var data Testdata
+			for it.Next(&data) {
+				codes = append(codes, data.Code)
+			}
+
+			Convey("It walks through all objects", func() {
+				So(it.Err(), ShouldBeNil)
+				So(codes, ShouldResemble, []int{1, 2, 3, 4, 5})
+			})
+
+			Convey("It pages by objectId", func() {
+				So(len(requests), ShouldEqual, 3)
+				So(requests[0]["where"], ShouldEqual, `{"code":{"$gt":0}}`)
+				So(requests[1]["where"], ShouldEqual, `{"code":{"$gt":0},"objectId":{"$gt":"obj2"}}`)
+				So(requests[2]["where"], ShouldEqual, `{"code":{"$gt":0},"objectId":{"$gt":"obj4"}}`)
+				for _, req := range requests {
+					So(req["order"], ShouldEqual, "objectId")
+					So(req["limit"], ShouldEqual, "2")
+					So(req["skip"], ShouldEqual, "")
+				}
+			})
+		})
+
+		Convey("When the last page is full", func() {
+
+			it := testingClass.Iterator(5)
+
+			count := 0
+			var data Testdata
+			for it.Next(&data) {
+				count++
+			}
+
+			Convey("It stops at an empty page", func() {
+				So(it.Err(), ShouldBeNil)
+				So(count, ShouldEqual, 5)
+				So(len(requests), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When some objects do not have a field", func() {
+
+			it := testingClass.Iterator(5)
+
+			var tags []string
+			var data struct {
+				Tag string `json:"tag"`
+			}
+			for it.Next(&data) {
+				tags = append(tags, data.Tag)
+			}
+
+			var keys []int
+			var m map[string]interface{}
+			it = testingClass.Iterator(5)
+			for it.Next(&m) {
+				keys = append(keys, len(m))
+			}
+
+			Convey("It does not leave the field of the previous object", func() {
+				So(it.Err(), ShouldBeNil)
+				So(tags, ShouldResemble, []string{"first", "", "", "", ""})
+				So(keys, ShouldResemble, []int{3, 2, 2, 2, 2})
+			})
+		})
+
+		Convey("When closing the iterator", func() {
+
+			it := testingClass.Iterator(2)
+
+			var data Testdata
+			So(it.Next(&data), ShouldBeTrue)
+			So(it.Close(), ShouldBeNil)
+
+			Convey("It stops iterating", func() {
+				So(it.Next(&data), ShouldBeFalse)
+				So(len(requests), ShouldEqual, 1)
+			})
+		})
+	})
+}
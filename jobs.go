@@ -0,0 +1,111 @@
+package goparse
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	classJobStatus = "_JobStatus"
+
+	// JobStatusRunning is the status of the running job
+	JobStatusRunning = "running"
+	// JobStatusSucceeded is the status of the job which has succeeded
+	JobStatusSucceeded = "succeeded"
+	// JobStatusFailed is the status of the job which has failed
+	JobStatusFailed = "failed"
+
+	defaultJobPollInterval = time.Second
+)
+
+var (
+	// ErrJobTimeout is returned when the job does not finish before the timeout
+	ErrJobTimeout = errors.New("job did not finish before the timeout")
+)
+
+// Done checks the job has finished whether it has succeeded or failed
+func (j JobStatus) Done() bool {
+	return j.Status != "" && j.Status != JobStatusRunning
+}
+
+// Duration returns how long the job has run. It is the time until now while the job is running.
+func (j JobStatus) Duration() time.Duration {
+	if j.FinishedAt != nil {
+		return j.FinishedAt.Sub(j.CreatedAt)
+	}
+	return time.Since(j.CreatedAt)
+}
+
+// StartJob starts the background job with the params by use master key,
+// and returns the ID of the job status to track the job.
+func (s *ParseSession) StartJob(name string, params interface{}) (string, error) {
+	if name == "" {
+		return "", errors.New("name must not be empty")
+	}
+	if s.client.MasterKey == "" {
+		return "", errors.New("request requires MasterKey")
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	req, err := sendJSON(s.post("/jobs/"+name, true), params)
+	if err != nil {
+		return "", err
+	}
+	res, body, errs := req.End()
+	if errs != nil {
+		return "", fmt.Errorf("%v", errs)
+	}
+	if err := decodeResponse(res.StatusCode, body, nil); err != nil {
+		return "", err
+	}
+	jobStatusID := res.Header.Get(headerJobStatusID)
+	if jobStatusID == "" {
+		return "", errors.New("job status ID is not returned")
+	}
+	return jobStatusID, nil
+}
+
+// GetJobStatus gets the status of the job by use master key
+func (s *ParseSession) GetJobStatus(jobStatusID string) (status JobStatus, err error) {
+	if jobStatusID == "" {
+		return status, errors.New("jobStatusID must not be empty")
+	}
+	if s.client.MasterKey == "" {
+		return status, errors.New("request requires MasterKey")
+	}
+	return status, do(s.get("/classes/"+classJobStatus+"/"+jobStatusID, true), &status)
+}
+
+// WaitJob polls the status of the job at the interval until the job finishes, and returns the last status.
+// The interval is a second if it is not positive. ErrJobTimeout is returned if the job does not finish
+// before the timeout, and no timeout is set if the timeout is not positive.
+// A failed job is not an error, so check the status of the result.
+func (s *ParseSession) WaitJob(jobStatusID string, interval time.Duration, timeout time.Duration) (JobStatus, error) {
+	if interval <= 0 {
+		interval = defaultJobPollInterval
+	}
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		status, err := s.GetJobStatus(jobStatusID)
+		if err != nil || status.Done() {
+			return status, err
+		}
+		wait := interval
+		if !deadline.IsZero() {
+			// Poll the last time at the deadline
+			remaining := deadline.Sub(time.Now())
+			if remaining <= 0 {
+				return status, ErrJobTimeout
+			}
+			if remaining < wait {
+				wait = remaining
+			}
+		}
+		time.Sleep(wait)
+	}
+}
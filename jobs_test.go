@@ -0,0 +1,171 @@
+package goparse
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestJobs(t *testing.T) {
+
+	Convey("Given a session", t, func() {
+
+		var method, path, rawBody string
+		var header http.Header
+		polls := 0
+		status := JobStatusRunning
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			path = r.URL.Path
+			header = r.Header
+			b, _ := ioutil.ReadAll(r.Body)
+			rawBody = string(b)
+
+			switch {
+			case r.Method == "POST" && r.URL.Path == "/jobs/nightly":
+				w.Header().Set(headerJobStatusID, "j1")
+				w.Write([]byte(`{}`))
+			case r.Method == "POST":
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":141,"error":"Invalid job."}`))
+			case r.Method == "GET":
+				polls++
+				if polls >= 3 && status != JobStatusRunning {
+					w.Write([]byte(`{
+						"objectId": "j1",
+						"jobName": "nightly",
+						"source": "api",
+						"status": "` + status + `",
+						"message": "done",
+						"params": {"date": "2016-10-12"},
+						"finishedAt": {"__type": "Date", "iso": "2016-10-12T08:32:09.030Z"},
+						"createdAt": "2016-10-12T08:31:59.030Z",
+						"updatedAt": "2016-10-12T08:32:09.030Z"
+					}`))
+					return
+				}
+				w.Write([]byte(`{"objectId":"j1","jobName":"nightly","status":"running","createdAt":"2016-10-12T08:31:59.030Z"}`))
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			MasterKey:     "MASTERKEY",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		session := client.NewSession("")
+
+		Convey("When starting a job", func() {
+
+			jobStatusID, err := session.StartJob("nightly", map[string]string{"date": "2016-10-12"})
+
+			Convey("It posts the params with the master key", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "POST")
+				So(path, ShouldEqual, "/jobs/nightly")
+				So(rawBody, ShouldEqual, `{"date":"2016-10-12"}`)
+				So(header.Get(headerMasterKey), ShouldEqual, "MASTERKEY")
+			})
+
+			Convey("It returns the job status ID", func() {
+				So(err, ShouldBeNil)
+				So(jobStatusID, ShouldEqual, "j1")
+			})
+		})
+
+		Convey("When starting an unknown job", func() {
+
+			_, err := session.StartJob("unknown", nil)
+
+			Convey("It returns the parse error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.(*Error).Code, ShouldEqual, 141)
+			})
+		})
+
+		Convey("When starting a job without the master key", func() {
+
+			client.MasterKey = ""
+			_, err := session.StartJob("nightly", nil)
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "request requires MasterKey")
+				So(method, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When getting the status of a running job", func() {
+
+			s, err := session.GetJobStatus("j1")
+
+			Convey("It gets the status from _JobStatus", func() {
+				So(err, ShouldBeNil)
+				So(path, ShouldEqual, "/classes/_JobStatus/j1")
+				So(s.Status, ShouldEqual, JobStatusRunning)
+				So(s.Done(), ShouldBeFalse)
+				So(s.FinishedAt, ShouldBeNil)
+				So(s.Duration(), ShouldBeGreaterThan, 0)
+			})
+		})
+
+		Convey("When waiting for a job which succeeds", func() {
+
+			status = JobStatusSucceeded
+			s, err := session.WaitJob("j1", time.Millisecond, time.Second)
+
+			Convey("It polls until the job finishes", func() {
+				So(err, ShouldBeNil)
+				So(polls, ShouldEqual, 3)
+				So(s.Done(), ShouldBeTrue)
+				So(s.Status, ShouldEqual, JobStatusSucceeded)
+				So(s.Message, ShouldEqual, "done")
+				So(s.Params["date"], ShouldEqual, "2016-10-12")
+				So(s.Duration(), ShouldEqual, 10*time.Second)
+			})
+		})
+
+		Convey("When waiting for a job which fails", func() {
+
+			status = JobStatusFailed
+			s, err := session.WaitJob("j1", time.Millisecond, 0)
+
+			Convey("It returns the failed status", func() {
+				So(err, ShouldBeNil)
+				So(s.Status, ShouldEqual, JobStatusFailed)
+			})
+		})
+
+		Convey("When the job does not finish before the timeout", func() {
+
+			s, err := session.WaitJob("j1", 10*time.Millisecond, 15*time.Millisecond)
+
+			Convey("It returns ErrJobTimeout", func() {
+				So(err, ShouldEqual, ErrJobTimeout)
+				So(s.Status, ShouldEqual, JobStatusRunning)
+			})
+		})
+
+		Convey("When the timeout is shorter than the interval", func() {
+
+			start := time.Now()
+			_, err := session.WaitJob("j1", time.Second, 50*time.Millisecond)
+			elapsed := time.Since(start)
+
+			Convey("It polls the last time at the timeout", func() {
+				So(err, ShouldEqual, ErrJobTimeout)
+				So(polls, ShouldEqual, 2)
+				So(elapsed, ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+				So(elapsed, ShouldBeLessThan, time.Second)
+			})
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package goparse
 
 import (
+	"encoding/json"
 	"strconv"
 	"time"
 )
@@ -53,6 +54,7 @@ type (
 		UserName string    `json:"username"`
 		Password string    `json:"password"`
 		AuthData *AuthData `json:"authData,omitempty"`
+		ACL      *ACL      `json:"ACL,omitempty"`
 	}
 
 	// Installation data type
@@ -75,6 +77,59 @@ type (
 		UpdatedAt      time.Time `json:"updatedAt,omitempty"`
 	}
 
+	// Session data type
+	Session struct {
+		ObjectID       string       `json:"objectId,omitempty"`
+		SessionToken   string       `json:"sessionToken,omitempty"`
+		User           *Pointer     `json:"user,omitempty"`
+		CreatedWith    *CreatedWith `json:"createdWith,omitempty"`
+		Restricted     bool         `json:"restricted,omitempty"`
+		ExpiresAt      *Date        `json:"expiresAt,omitempty"`
+		InstallationID string       `json:"installationId,omitempty"`
+		CreatedAt      time.Time    `json:"createdAt,omitempty"`
+		UpdatedAt      time.Time    `json:"updatedAt,omitempty"`
+	}
+
+	// CreatedWith data type
+	CreatedWith struct {
+		Action       string `json:"action,omitempty"`
+		AuthProvider string `json:"authProvider,omitempty"`
+	}
+
+	// Date data type
+	Date struct {
+		time.Time
+	}
+
+	// Role data type
+	Role struct {
+		ObjectID  string    `json:"objectId,omitempty"`
+		Name      string    `json:"name,omitempty"`
+		ACL       *ACL      `json:"ACL,omitempty"`
+		CreatedAt time.Time `json:"createdAt,omitempty"`
+		UpdatedAt time.Time `json:"updatedAt,omitempty"`
+	}
+
+	// Config data type of Parse Config.
+	// MasterKeyOnly has the names of the params which are got only by use master key.
+	Config struct {
+		Params        map[string]interface{} `json:"params"`
+		MasterKeyOnly map[string]bool        `json:"masterKeyOnly,omitempty"`
+	}
+
+	// JobStatus data type of a background job. FinishedAt is nil while the job is running.
+	JobStatus struct {
+		ObjectID   string                 `json:"objectId,omitempty"`
+		JobName    string                 `json:"jobName,omitempty"`
+		Source     string                 `json:"source,omitempty"`
+		Status     string                 `json:"status,omitempty"`
+		Message    string                 `json:"message,omitempty"`
+		Params     map[string]interface{} `json:"params,omitempty"`
+		FinishedAt *Date                  `json:"finishedAt,omitempty"`
+		CreatedAt  time.Time              `json:"createdAt,omitempty"`
+		UpdatedAt  time.Time              `json:"updatedAt,omitempty"`
+	}
+
 	// Pointer data type
 	Pointer struct {
 		Type      string `json:"__type"`
@@ -82,6 +137,28 @@ type (
 		ObjectID  string `json:"objectId"`
 	}
 
+	// Relation data type
+	Relation struct {
+		ClassName string `json:"className"`
+	}
+
+	// GeoPoint data type
+	GeoPoint struct {
+		Latitude  float64 `json:"latitude"`
+		Longitude float64 `json:"longitude"`
+	}
+
+	// Polygon data type
+	Polygon struct {
+		Points []GeoPoint
+	}
+
+	// File data type. Name is the name given by the server when the file is uploaded.
+	File struct {
+		Name string `json:"name"`
+		URL  string `json:"url,omitempty"`
+	}
+
 	// PushNotificationQuery data type.
 	// You can set the push_time and expiration_time to either "2015-08-022T12:00:00.000Z"
 	// or 1440226800.
@@ -101,6 +178,13 @@ type (
 		UpdatedAt    time.Time `json:"updatedAt,omitempty"`
 	}
 
+	// ListResponse data type of the list queries.
+	// Set a pointer to a slice to Results to decode the results into it.
+	ListResponse struct {
+		Results interface{} `json:"results"`
+		Count   int         `json:"count,omitempty"`
+	}
+
 	// Error data type
 	Error struct {
 		Code    int    `json:"code"`
@@ -112,3 +196,88 @@ type (
 func (err *Error) Error() string {
 	return err.Message + " - code:" + strconv.Itoa(err.Code)
 }
+
+// NewPointer creates a pointer to the object of the class
+func NewPointer(className string, objectID string) Pointer {
+	return Pointer{
+		Type:      "Pointer",
+		ClassName: className,
+		ObjectID:  objectID,
+	}
+}
+
+// MarshalJSON encodes Date with __type
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"__type": "Date",
+		"iso":    d.UTC().Format("2006-01-02T15:04:05.000Z"),
+	})
+}
+
+// UnmarshalJSON decodes Date from iso
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var v struct {
+		ISO time.Time `json:"iso"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	d.Time = v.ISO
+	return nil
+}
+
+// MarshalJSON encodes Relation with __type
+func (r Relation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"__type":    "Relation",
+		"className": r.ClassName,
+	})
+}
+
+// MarshalJSON encodes File with __type
+func (f File) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{
+		"__type": "File",
+		"name":   f.Name,
+	}
+	if f.URL != "" {
+		v["url"] = f.URL
+	}
+	return json.Marshal(v)
+}
+
+// MarshalJSON encodes GeoPoint with __type
+func (p GeoPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"__type":    "GeoPoint",
+		"latitude":  p.Latitude,
+		"longitude": p.Longitude,
+	})
+}
+
+// MarshalJSON encodes Polygon with __type and coordinates of [latitude, longitude]
+func (p Polygon) MarshalJSON() ([]byte, error) {
+	coordinates := make([][2]float64, len(p.Points))
+	for i, point := range p.Points {
+		coordinates[i] = [2]float64{point.Latitude, point.Longitude}
+	}
+	return json.Marshal(map[string]interface{}{
+		"__type":      "Polygon",
+		"coordinates": coordinates,
+	})
+}
+
+// UnmarshalJSON decodes Polygon from coordinates of [latitude, longitude]
+func (p *Polygon) UnmarshalJSON(b []byte) error {
+	var v struct {
+		Coordinates [][2]float64 `json:"coordinates"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	p.Points = make([]GeoPoint, len(v.Coordinates))
+	for i, c := range v.Coordinates {
+		p.Points[i] = GeoPoint{Latitude: c[0], Longitude: c[1]}
+	}
+	return nil
+}
@@ -137,6 +137,71 @@ func TestModel(t *testing.T) {
 			})
 		})
 
+		Convey("When decoding as ListResponse", func() {
+
+			s := `{
+				"results": [
+					{"objectId": "abc", "username": "test1"},
+					{"objectId": "def", "username": "test2"}
+				],
+				"count": 10
+			}`
+
+			var users []User
+			err := json.NewDecoder(strings.NewReader(s)).Decode(&ListResponse{Results: &users})
+			So(err, ShouldEqual, nil)
+
+			Convey("It has Results", func() {
+				So(len(users), ShouldEqual, 2)
+				So(users[0].ObjectID, ShouldEqual, "abc")
+				So(users[1].UserName, ShouldEqual, "test2")
+			})
+
+			Convey("It has a Count", func() {
+				l := ListResponse{Results: &users}
+				json.NewDecoder(strings.NewReader(s)).Decode(&l)
+				So(l.Count, ShouldEqual, 10)
+			})
+		})
+
+		Convey("When decoding as GeoPoint", func() {
+
+			s := `{
+				"__type": "GeoPoint",
+				"latitude": 35.6581,
+				"longitude": 139.7017
+			}`
+
+			var p GeoPoint
+			err := json.NewDecoder(strings.NewReader(s)).Decode(&p)
+			So(err, ShouldEqual, nil)
+
+			Convey("It has a Latitude and a Longitude", func() {
+				So(p.Latitude, ShouldEqual, 35.6581)
+				So(p.Longitude, ShouldEqual, 139.7017)
+			})
+		})
+
+		Convey("When decoding as Polygon", func() {
+
+			s := `{
+				"__type": "Polygon",
+				"coordinates": [[0, 0], [0, 1], [1, 1]]
+			}`
+
+			var p Polygon
+			err := json.NewDecoder(strings.NewReader(s)).Decode(&p)
+			So(err, ShouldEqual, nil)
+
+			Convey("It has Points", func() {
+				So(p.Points, ShouldResemble, []GeoPoint{
+					{Latitude: 0, Longitude: 0},
+					{Latitude: 0, Longitude: 1},
+					{Latitude: 1, Longitude: 1},
+				})
+			})
+		})
+
 		Convey("When deocding as Error", func() {
 
 			s := `{
@@ -159,4 +224,41 @@ func TestModel(t *testing.T) {
 		})
 
 	})
+
+	Convey("Given data types", t, func() {
+
+		Convey("When encoding Relation", func() {
+
+			b, err := json.Marshal(Relation{ClassName: "_User"})
+			So(err, ShouldEqual, nil)
+
+			Convey("It has a __type", func() {
+				So(string(b), ShouldEqual, `{"__type":"Relation","className":"_User"}`)
+			})
+		})
+
+		Convey("When encoding GeoPoint", func() {
+
+			b, err := json.Marshal(GeoPoint{Latitude: 35.6581, Longitude: 139.7017})
+			So(err, ShouldEqual, nil)
+
+			Convey("It has a __type", func() {
+				So(string(b), ShouldEqual, `{"__type":"GeoPoint","latitude":35.6581,"longitude":139.7017}`)
+			})
+		})
+
+		Convey("When encoding Polygon", func() {
+
+			b, err := json.Marshal(Polygon{Points: []GeoPoint{
+				{Latitude: 0, Longitude: 0},
+				{Latitude: 0, Longitude: 1},
+				{Latitude: 1, Longitude: 1},
+			}})
+			So(err, ShouldEqual, nil)
+
+			Convey("It has a __type and coordinates", func() {
+				So(string(b), ShouldEqual, `{"__type":"Polygon","coordinates":[[0,0],[0,1],[1,1]]}`)
+			})
+		})
+	})
 }
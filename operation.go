@@ -0,0 +1,76 @@
+package goparse
+
+import (
+	"encoding/json"
+)
+
+// Operation is an atomic operation on a field, which can be set to the data of updates
+type Operation struct {
+	Op      string
+	Amount  float64
+	Objects []interface{}
+}
+
+// Increment creates an operation which increments the number field by the amount.
+// Use a negative amount to decrement.
+func Increment(amount float64) Operation {
+	return Operation{Op: "Increment", Amount: amount}
+}
+
+// Add creates an operation which appends the items to the array field
+func Add(items ...interface{}) Operation {
+	return Operation{Op: "Add", Objects: items}
+}
+
+// AddUnique creates an operation which appends the items to the array field if they are not contained
+func AddUnique(items ...interface{}) Operation {
+	return Operation{Op: "AddUnique", Objects: items}
+}
+
+// Remove creates an operation which removes all the items from the array field
+func Remove(items ...interface{}) Operation {
+	return Operation{Op: "Remove", Objects: items}
+}
+
+// DeleteField creates an operation which deletes the field
+func DeleteField() Operation {
+	return Operation{Op: "Delete"}
+}
+
+// AddRelation creates an operation which adds the objects to the relation field
+func AddRelation(pointers ...Pointer) Operation {
+	return Operation{Op: "AddRelation", Objects: pointerObjects(pointers)}
+}
+
+// RemoveRelation creates an operation which removes the objects from the relation field
+func RemoveRelation(pointers ...Pointer) Operation {
+	return Operation{Op: "RemoveRelation", Objects: pointerObjects(pointers)}
+}
+
+// Convert pointers to objects of the operation
+func pointerObjects(pointers []Pointer) []interface{} {
+	objects := make([]interface{}, len(pointers))
+	for i, p := range pointers {
+		objects[i] = p
+	}
+	return objects
+}
+
+// MarshalJSON encodes Operation with __op
+func (o Operation) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{
+		"__op": o.Op,
+	}
+	switch o.Op {
+	case "Increment":
+		v["amount"] = o.Amount
+	case "Delete":
+	default:
+		objects := o.Objects
+		if objects == nil {
+			objects = []interface{}{}
+		}
+		v["objects"] = objects
+	}
+	return json.Marshal(v)
+}
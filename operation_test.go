@@ -0,0 +1,83 @@
+package goparse
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOperation(t *testing.T) {
+
+	Convey("Given operations", t, func() {
+
+		Convey("When encoding Increment", func() {
+
+			b, err := json.Marshal(Increment(2))
+			So(err, ShouldBeNil)
+
+			Convey("It has an amount", func() {
+				So(string(b), ShouldEqual, `{"__op":"Increment","amount":2}`)
+			})
+
+			Convey("It can decrement", func() {
+				b, _ := json.Marshal(Increment(-1.5))
+				So(string(b), ShouldEqual, `{"__op":"Increment","amount":-1.5}`)
+			})
+		})
+
+		Convey("When encoding array operations", func() {
+
+			Convey("It has objects", func() {
+				b, _ := json.Marshal(Add("a", 1))
+				So(string(b), ShouldEqual, `{"__op":"Add","objects":["a",1]}`)
+
+				b, _ = json.Marshal(AddUnique("a"))
+				So(string(b), ShouldEqual, `{"__op":"AddUnique","objects":["a"]}`)
+
+				b, _ = json.Marshal(Remove())
+				So(string(b), ShouldEqual, `{"__op":"Remove","objects":[]}`)
+			})
+		})
+
+		Convey("When encoding relation operations", func() {
+
+			Convey("It has pointers", func() {
+				b, _ := json.Marshal(AddRelation(NewPointer("_User", "abc"), NewPointer("_User", "def")))
+				So(string(b), ShouldEqual, `{"__op":"AddRelation","objects":[`+
+					`{"__type":"Pointer","className":"_User","objectId":"abc"},`+
+					`{"__type":"Pointer","className":"_User","objectId":"def"}]}`)
+
+				b, _ = json.Marshal(RemoveRelation(NewPointer("_User", "abc")))
+				So(string(b), ShouldEqual, `{"__op":"RemoveRelation","objects":[`+
+					`{"__type":"Pointer","className":"_User","objectId":"abc"}]}`)
+			})
+		})
+
+		Convey("When encoding DeleteField", func() {
+
+			b, err := json.Marshal(DeleteField())
+			So(err, ShouldBeNil)
+
+			Convey("It has only __op", func() {
+				So(string(b), ShouldEqual, `{"__op":"Delete"}`)
+			})
+		})
+
+		Convey("When mixing into update data", func() {
+
+			b, err := json.Marshal(map[string]interface{}{
+				"name":  "apple",
+				"count": Increment(1),
+				"tags":  AddUnique("red"),
+				"key":   DeleteField(),
+			})
+			So(err, ShouldBeNil)
+
+			Convey("It encodes operations with other values", func() {
+				So(string(b), ShouldEqual, `{"count":{"__op":"Increment","amount":1},`+
+					`"key":{"__op":"Delete"},"name":"apple","tags":{"__op":"AddUnique","objects":["red"]}}`)
+			})
+		})
+	})
+}
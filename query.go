@@ -0,0 +1,467 @@
+package goparse
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Query is a query builder for the ParseClass
+type Query struct {
+	class       *ParseClass
+	where       map[string]interface{}
+	operators   map[string]bool // keys whose conditions are the operator maps of the query
+	order       []string
+	limit       int
+	skip        int
+	keys        []string
+	excludeKeys []string
+	include     []string
+	err         error // error of building the query, which is returned when the query is run
+}
+
+// TextSearchOptions is the options of the full-text search
+type TextSearchOptions struct {
+	Language           string
+	CaseSensitive      bool
+	DiacriticSensitive bool
+}
+
+// NewQuery creates a new query from the class
+func (c *ParseClass) NewQuery() *Query {
+	return &Query{
+		class:     c,
+		where:     map[string]interface{}{},
+		operators: map[string]bool{},
+		limit:     -1,
+	}
+}
+
+// Or creates a query which matches any of the queries
+func Or(queries ...*Query) (*Query, error) {
+	return compound("$or", queries)
+}
+
+// And creates a query which matches all of the queries
+func And(queries ...*Query) (*Query, error) {
+	return compound("$and", queries)
+}
+
+// Nor creates a query which matches none of the queries
+func Nor(queries ...*Query) (*Query, error) {
+	return compound("$nor", queries)
+}
+
+// Create a query combining the queries by the operator.
+// The conditions of the queries are copied, so changing the queries later does not affect the result.
+// The queries must not have the options such as the order and the limit, because they are
+// not applied to the queries combined.
+func compound(op string, queries []*Query) (*Query, error) {
+	if len(queries) == 0 {
+		return nil, errors.New("queries must not be empty")
+	}
+	for _, q := range queries {
+		if q == nil {
+			return nil, errors.New("queries must not contain nil")
+		}
+	}
+	class := queries[0].class
+	wheres := make([]map[string]interface{}, len(queries))
+	for i, q := range queries {
+		if q.err != nil {
+			return nil, q.err
+		}
+		if q.class.Name != class.Name {
+			return nil, errors.New("queries must target the same class")
+		}
+		if q.hasOptions() {
+			return nil, errors.New("queries must not have order, limit, skip, keys or include")
+		}
+		wheres[i] = copyWhere(q.where)
+	}
+	q := class.NewQuery()
+	q.where[op] = wheres
+	return q, nil
+}
+
+// Check the query has the options other than the conditions
+func (q *Query) hasOptions() bool {
+	return len(q.order) > 0 || q.limit >= 0 || q.skip > 0 ||
+		len(q.keys) > 0 || len(q.excludeKeys) > 0 || len(q.include) > 0
+}
+
+// Copy the where conditions deeply
+func copyWhere(where map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(where))
+	for key, value := range where {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			c[key] = copyWhere(v)
+		case []map[string]interface{}:
+			wheres := make([]map[string]interface{}, len(v))
+			for i, w := range v {
+				wheres[i] = copyWhere(w)
+			}
+			c[key] = wheres
+		default:
+			c[key] = v
+		}
+	}
+	return c
+}
+
+// Class returns the class which the query targets
+func (q *Query) Class() *ParseClass {
+	return q.class
+}
+
+// Where returns the where conditions of the query
+func (q *Query) Where() map[string]interface{} {
+	return q.where
+}
+
+// Add an operator condition to the key.
+// The value which the key already equals to is kept as $eq in the operator map.
+func (q *Query) addCondition(key string, op string, value interface{}) *Query {
+	if !q.operators[key] {
+		cond := map[string]interface{}{}
+		if current, ok := q.where[key]; ok {
+			cond["$eq"] = current
+		}
+		q.where[key] = cond
+		q.operators[key] = true
+	}
+	q.where[key].(map[string]interface{})[op] = value
+	return q
+}
+
+// EqualTo adds a constraint that the key equals to the value
+func (q *Query) EqualTo(key string, value interface{}) *Query {
+	if q.operators[key] {
+		return q.addCondition(key, "$eq", value)
+	}
+	q.where[key] = value
+	return q
+}
+
+// NotEqualTo adds a constraint that the key does not equal to the value
+func (q *Query) NotEqualTo(key string, value interface{}) *Query {
+	return q.addCondition(key, "$ne", value)
+}
+
+// LessThan adds a constraint that the key is less than the value
+func (q *Query) LessThan(key string, value interface{}) *Query {
+	return q.addCondition(key, "$lt", value)
+}
+
+// LessThanOrEqual adds a constraint that the key is less than or equal to the value
+func (q *Query) LessThanOrEqual(key string, value interface{}) *Query {
+	return q.addCondition(key, "$lte", value)
+}
+
+// GreaterThan adds a constraint that the key is greater than the value
+func (q *Query) GreaterThan(key string, value interface{}) *Query {
+	return q.addCondition(key, "$gt", value)
+}
+
+// GreaterThanOrEqual adds a constraint that the key is greater than or equal to the value
+func (q *Query) GreaterThanOrEqual(key string, value interface{}) *Query {
+	return q.addCondition(key, "$gte", value)
+}
+
+// ContainedIn adds a constraint that the key is contained in the values
+func (q *Query) ContainedIn(key string, values ...interface{}) *Query {
+	return q.addCondition(key, "$in", values)
+}
+
+// NotContainedIn adds a constraint that the key is not contained in the values
+func (q *Query) NotContainedIn(key string, values ...interface{}) *Query {
+	return q.addCondition(key, "$nin", values)
+}
+
+// ContainsAll adds a constraint that the array of the key contains all the values
+func (q *Query) ContainsAll(key string, values ...interface{}) *Query {
+	return q.addCondition(key, "$all", values)
+}
+
+// Exists adds a constraint that the key is set
+func (q *Query) Exists(key string) *Query {
+	return q.addCondition(key, "$exists", true)
+}
+
+// DoesNotExist adds a constraint that the key is not set
+func (q *Query) DoesNotExist(key string) *Query {
+	return q.addCondition(key, "$exists", false)
+}
+
+// StartsWith adds a constraint that the string of the key starts with the prefix
+func (q *Query) StartsWith(key string, prefix string) *Query {
+	return q.addCondition(key, "$regex", "^"+regexp.QuoteMeta(prefix))
+}
+
+// Matches adds a constraint that the string of the key matches the regular expression.
+// The modifiers are the regex options such as "i" or "m", and may be empty.
+func (q *Query) Matches(key string, regex string, modifiers string) *Query {
+	q.addCondition(key, "$regex", regex)
+	if modifiers != "" {
+		q.addCondition(key, "$options", modifiers)
+	}
+	return q
+}
+
+// MatchesQuery adds a constraint that the pointer of the key matches the query of another class
+func (q *Query) MatchesQuery(key string, query *Query) *Query {
+	return q.addCondition(key, "$inQuery", q.subquery(query))
+}
+
+// DoesNotMatchQuery adds a constraint that the pointer of the key does not match the query of another class
+func (q *Query) DoesNotMatchQuery(key string, query *Query) *Query {
+	return q.addCondition(key, "$notInQuery", q.subquery(query))
+}
+
+// MatchesKeyInQuery adds a constraint that the value of the key equals to the value of queryKey
+// in the results of the query
+func (q *Query) MatchesKeyInQuery(key string, queryKey string, query *Query) *Query {
+	return q.addCondition(key, "$select", map[string]interface{}{
+		"query": q.subquery(query),
+		"key":   queryKey,
+	})
+}
+
+// DoesNotMatchKeyInQuery adds a constraint that the value of the key does not equal to the value
+// of queryKey in the results of the query
+func (q *Query) DoesNotMatchKeyInQuery(key string, queryKey string, query *Query) *Query {
+	return q.addCondition(key, "$dontSelect", map[string]interface{}{
+		"query": q.subquery(query),
+		"key":   queryKey,
+	})
+}
+
+// RelatedTo adds a constraint that the objects are in the relation of the key of the object
+func (q *Query) RelatedTo(object Pointer, key string) *Query {
+	q.where["$relatedTo"] = map[string]interface{}{
+		"object": object,
+		"key":    key,
+	}
+	return q
+}
+
+// FullText adds a constraint that the string of the key matches the term by the full-text search.
+// The options may be nil.
+func (q *Query) FullText(key string, term string, options *TextSearchOptions) *Query {
+	search := map[string]interface{}{
+		"$term": term,
+	}
+	if options != nil {
+		if options.Language != "" {
+			search["$language"] = options.Language
+		}
+		if options.CaseSensitive {
+			search["$caseSensitive"] = true
+		}
+		if options.DiacriticSensitive {
+			search["$diacriticSensitive"] = true
+		}
+	}
+	return q.addCondition(key, "$text", map[string]interface{}{
+		"$search": search,
+	})
+}
+
+// SortByTextScore sorts the results by the score of the full-text search and selects the score
+func (q *Query) SortByTextScore() *Query {
+	return q.Ascending("$score").Keys("$score")
+}
+
+// Near adds a constraint that the point of the key is near the point, and sorts the results by distance
+func (q *Query) Near(key string, point GeoPoint) *Query {
+	return q.addCondition(key, "$nearSphere", point)
+}
+
+// WithinMiles adds a constraint that the point of the key is within maxDistance miles from the point
+func (q *Query) WithinMiles(key string, point GeoPoint, maxDistance float64) *Query {
+	q.addCondition(key, "$nearSphere", point)
+	return q.addCondition(key, "$maxDistanceInMiles", maxDistance)
+}
+
+// WithinKilometers adds a constraint that the point of the key is within maxDistance kilometers from the point
+func (q *Query) WithinKilometers(key string, point GeoPoint, maxDistance float64) *Query {
+	q.addCondition(key, "$nearSphere", point)
+	return q.addCondition(key, "$maxDistanceInKilometers", maxDistance)
+}
+
+// WithinRadians adds a constraint that the point of the key is within maxDistance radians from the point
+func (q *Query) WithinRadians(key string, point GeoPoint, maxDistance float64) *Query {
+	q.addCondition(key, "$nearSphere", point)
+	return q.addCondition(key, "$maxDistanceInRadians", maxDistance)
+}
+
+// WithinGeoBox adds a constraint that the point of the key is within the rectangular box
+func (q *Query) WithinGeoBox(key string, southwest GeoPoint, northeast GeoPoint) *Query {
+	return q.addCondition(key, "$within", map[string]interface{}{
+		"$box": []GeoPoint{southwest, northeast},
+	})
+}
+
+// WithinPolygon adds a constraint that the point of the key is within the polygon
+func (q *Query) WithinPolygon(key string, points ...GeoPoint) *Query {
+	return q.addCondition(key, "$geoWithin", map[string]interface{}{
+		"$polygon": points,
+	})
+}
+
+// WithinCenterSphere adds a constraint that the point of the key is within the circle
+// of the center and the radius in radians
+func (q *Query) WithinCenterSphere(key string, center GeoPoint, radius float64) *Query {
+	return q.addCondition(key, "$geoWithin", map[string]interface{}{
+		"$centerSphere": []interface{}{center, radius},
+	})
+}
+
+// PolygonContains adds a constraint that the polygon of the key contains the point
+func (q *Query) PolygonContains(key string, point GeoPoint) *Query {
+	return q.addCondition(key, "$geoIntersects", map[string]interface{}{
+		"$point": point,
+	})
+}
+
+// Make the query a subquery of the query. The conditions of the subquery are copied.
+// The subquery must not have the options such as the order and the limit, because they are
+// not applied to the subquery. The error is recorded to the query and returned when the query is run.
+func (q *Query) subquery(query *Query) map[string]interface{} {
+	switch {
+	case query == nil:
+		q.fail(errors.New("subquery must not be nil"))
+	case query.err != nil:
+		q.fail(query.err)
+	case query.hasOptions():
+		q.fail(errors.New("subquery must not have order, limit, skip, keys or include"))
+	default:
+		return map[string]interface{}{
+			"className": query.class.Name,
+			"where":     copyWhere(query.where),
+		}
+	}
+	return nil
+}
+
+// Record the first error of building the query
+func (q *Query) fail(err error) {
+	if q.err == nil {
+		q.err = err
+	}
+}
+
+// Ascending sorts the results in ascending order by the keys
+func (q *Query) Ascending(keys ...string) *Query {
+	q.order = append(q.order, keys...)
+	return q
+}
+
+// Descending sorts the results in descending order by the keys
+func (q *Query) Descending(keys ...string) *Query {
+	for _, key := range keys {
+		q.order = append(q.order, "-"+key)
+	}
+	return q
+}
+
+// Limit sets the maximum number of results
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// Skip sets the number of results to skip
+func (q *Query) Skip(n int) *Query {
+	q.skip = n
+	return q
+}
+
+// Keys restricts the fields of the results to the keys
+func (q *Query) Keys(keys ...string) *Query {
+	q.keys = append(q.keys, keys...)
+	return q
+}
+
+// ExcludeKeys excludes the keys from the fields of the results
+func (q *Query) ExcludeKeys(keys ...string) *Query {
+	q.excludeKeys = append(q.excludeKeys, keys...)
+	return q
+}
+
+// Include includes the objects of the pointer keys in the results
+func (q *Query) Include(keys ...string) *Query {
+	q.include = append(q.include, keys...)
+	return q
+}
+
+// Values returns the URL query values of the query, or the error of building the query
+func (q *Query) Values() (url.Values, error) {
+	if q.err != nil {
+		return nil, q.err
+	}
+	vals := url.Values{}
+	if len(q.where) > 0 {
+		b, err := json.Marshal(q.where)
+		if err != nil {
+			return nil, err
+		}
+		vals.Set("where", string(b))
+	}
+	if len(q.order) > 0 {
+		vals.Set("order", strings.Join(q.order, ","))
+	}
+	if q.limit >= 0 {
+		vals.Set("limit", strconv.Itoa(q.limit))
+	}
+	if q.skip > 0 {
+		vals.Set("skip", strconv.Itoa(q.skip))
+	}
+	if len(q.keys) > 0 {
+		vals.Set("keys", strings.Join(q.keys, ","))
+	}
+	if len(q.excludeKeys) > 0 {
+		vals.Set("excludeKeys", strings.Join(q.excludeKeys, ","))
+	}
+	if len(q.include) > 0 {
+		vals.Set("include", strings.Join(q.include, ","))
+	}
+	return vals, nil
+}
+
+// Find gets class data which matches the query
+func (q *Query) Find(result interface{}) error {
+	vals, err := q.Values()
+	if err != nil {
+		return err
+	}
+	return q.class.selectValues(vals, result)
+}
+
+// FindList gets class data which matches the query into the provided slice such as *[]T
+func (q *Query) FindList(results interface{}) error {
+	return q.Find(&ListResponse{Results: results})
+}
+
+// Count counts class data which matches the query
+func (q *Query) Count() (int, error) {
+	vals, err := q.Values()
+	if err != nil {
+		return 0, err
+	}
+	vals.Set("limit", "0")
+	return q.class.countValues(vals, nil)
+}
+
+// FindWithCount gets class data which matches the query with the total number of them
+func (q *Query) FindWithCount(result interface{}) (int, error) {
+	vals, err := q.Values()
+	if err != nil {
+		return 0, err
+	}
+	return q.class.countValues(vals, result)
+}
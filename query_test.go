@@ -0,0 +1,368 @@
+package goparse
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestQuery(t *testing.T) {
+
+	Convey("Given a class", t, func() {
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			RESTAPIKey:    "APIKEY",
+		})
+		So(err, ShouldBeNil)
+
+		testingClass := client.NewSession("").NewClass("Testdata")
+
+		Convey("When creating a query", func() {
+
+			q := testingClass.NewQuery()
+
+			Convey("It targets the class", func() {
+				So(q.Class(), ShouldEqual, testingClass)
+				So(q.Where(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When adding constraints", func() {
+
+			q := testingClass.NewQuery().
+				EqualTo("name", "apple").
+				NotEqualTo("key", "hoge").
+				GreaterThan("code", 100).
+				LessThanOrEqual("code", 300).
+				ContainedIn("color", "red", "green").
+				NotContainedIn("size", 1, 2).
+				ContainsAll("tags", "a", "b").
+				Exists("owner").
+				DoesNotExist("deletedAt")
+
+			b, err := json.Marshal(q.Where())
+			So(err, ShouldBeNil)
+
+			Convey("It compiles to where JSON", func() {
+				So(string(b), ShouldEqual, `{`+
+					`"code":{"$gt":100,"$lte":300},`+
+					`"color":{"$in":["red","green"]},`+
+					`"deletedAt":{"$exists":false},`+
+					`"key":{"$ne":"hoge"},`+
+					`"name":"apple",`+
+					`"owner":{"$exists":true},`+
+					`"size":{"$nin":[1,2]},`+
+					`"tags":{"$all":["a","b"]}}`)
+			})
+		})
+
+		Convey("When adding regex constraints", func() {
+
+			q := testingClass.NewQuery().
+				StartsWith("name", "a.b").
+				Matches("key", "^ho", "i")
+
+			b, err := json.Marshal(q.Where())
+			So(err, ShouldBeNil)
+
+			Convey("It compiles to where JSON", func() {
+				So(string(b), ShouldEqual, `{"key":{"$options":"i","$regex":"^ho"},"name":{"$regex":"^a\\.b"}}`)
+			})
+		})
+
+		Convey("When setting query options", func() {
+
+			q := testingClass.NewQuery().
+				EqualTo("name", "apple").
+				Descending("code").
+				Ascending("createdAt", "name").
+				Limit(10).
+				Skip(20).
+				Keys("code", "name").
+				ExcludeKeys("key").
+				Include("owner", "owner.group")
+
+			vals, err := q.Values()
+			So(err, ShouldBeNil)
+
+			Convey("It encodes options alongside where", func() {
+				So(vals.Get("where"), ShouldEqual, `{"name":"apple"}`)
+				So(vals.Get("order"), ShouldEqual, "-code,createdAt,name")
+				So(vals.Get("limit"), ShouldEqual, "10")
+				So(vals.Get("skip"), ShouldEqual, "20")
+				So(vals.Get("keys"), ShouldEqual, "code,name")
+				So(vals.Get("excludeKeys"), ShouldEqual, "key")
+				So(vals.Get("include"), ShouldEqual, "owner,owner.group")
+			})
+		})
+
+		Convey("When no options are set", func() {
+
+			vals, err := testingClass.NewQuery().Values()
+			So(err, ShouldBeNil)
+
+			Convey("It encodes nothing", func() {
+				So(vals, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When combining queries", func() {
+
+			q1 := testingClass.NewQuery().EqualTo("name", "apple")
+			q2 := testingClass.NewQuery().GreaterThan("code", 200)
+
+			Convey("It compiles $or", func() {
+				q, err := Or(q1, q2)
+				So(err, ShouldBeNil)
+				So(q.Class(), ShouldEqual, testingClass)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"$or":[{"name":"apple"},{"code":{"$gt":200}}]}`)
+			})
+
+			Convey("It compiles $and", func() {
+				q, err := And(q1, q2)
+				So(err, ShouldBeNil)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"$and":[{"name":"apple"},{"code":{"$gt":200}}]}`)
+			})
+
+			Convey("It compiles $nor", func() {
+				q, err := Nor(q1, q2)
+				So(err, ShouldBeNil)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"$nor":[{"name":"apple"},{"code":{"$gt":200}}]}`)
+			})
+
+			Convey("It can be constrained further", func() {
+				q, err := Or(q1, q2)
+				So(err, ShouldBeNil)
+				q.Exists("key")
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"$or":[{"name":"apple"},{"code":{"$gt":200}}],"key":{"$exists":true}}`)
+			})
+
+			Convey("It returns an error without queries", func() {
+				q, err := Or()
+				So(q, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "queries must not be empty")
+			})
+
+			Convey("It returns an error for other classes", func() {
+				q3 := client.NewSession("").NewClass("Otherdata").NewQuery()
+				q, err := Or(q1, q3)
+				So(q, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "queries must target the same class")
+			})
+
+			Convey("It returns an error for nil queries", func() {
+				q, err := Or(q1, nil)
+				So(q, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "queries must not contain nil")
+			})
+
+			Convey("It returns an error for queries with options", func() {
+				q, err := Or(q1, testingClass.NewQuery().Limit(10))
+				So(q, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("It is not affected by changing the queries later", func() {
+				q, err := Or(q1, q2)
+				So(err, ShouldBeNil)
+				q1.EqualTo("code", 3)
+				q2.LessThan("code", 300)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"$or":[{"name":"apple"},{"code":{"$gt":200}}]}`)
+			})
+		})
+
+		Convey("When adding relational constraints", func() {
+
+			users := client.NewSession("").NewClass("_User").NewQuery().EqualTo("group", "admin")
+
+			Convey("It compiles $inQuery", func() {
+				q := testingClass.NewQuery().MatchesQuery("owner", users)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"owner":{"$inQuery":{"className":"_User","where":{"group":"admin"}}}}`)
+			})
+
+			Convey("It compiles $notInQuery", func() {
+				q := testingClass.NewQuery().DoesNotMatchQuery("owner", users)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"owner":{"$notInQuery":{"className":"_User","where":{"group":"admin"}}}}`)
+			})
+
+			Convey("It compiles $select", func() {
+				q := testingClass.NewQuery().MatchesKeyInQuery("name", "username", users)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"name":{"$select":{"key":"username","query":{"className":"_User","where":{"group":"admin"}}}}}`)
+			})
+
+			Convey("It compiles $dontSelect", func() {
+				q := testingClass.NewQuery().DoesNotMatchKeyInQuery("name", "username", users)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"name":{"$dontSelect":{"key":"username","query":{"className":"_User","where":{"group":"admin"}}}}}`)
+			})
+
+			Convey("It returns an error for a nil subquery", func() {
+				q := testingClass.NewQuery().MatchesQuery("owner", nil)
+				vals, err := q.Values()
+				So(vals, ShouldBeNil)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "subquery must not be nil")
+			})
+
+			Convey("It returns an error for a subquery with options", func() {
+				q := testingClass.NewQuery().MatchesKeyInQuery("name", "username", users.Ascending("username").Limit(1))
+				var results []map[string]interface{}
+				err := q.FindList(&results)
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "subquery must not have order, limit, skip, keys or include")
+				So(q.Distinct("name", &results), ShouldEqual, err)
+				_, err = Or(q)
+				So(err, ShouldNotBeNil)
+			})
+
+			Convey("It is not affected by changing the subquery later", func() {
+				q := testingClass.NewQuery().MatchesQuery("owner", users)
+				users.EqualTo("group", "guest")
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"owner":{"$inQuery":{"className":"_User","where":{"group":"admin"}}}}`)
+			})
+		})
+
+		Convey("When adding a relation constraint", func() {
+
+			q := testingClass.NewQuery().RelatedTo(NewPointer("Post", "abc"), "likes")
+			b, _ := json.Marshal(q.Where())
+
+			Convey("It compiles $relatedTo", func() {
+				So(string(b), ShouldEqual, `{"$relatedTo":{"key":"likes","object":{"__type":"Pointer","className":"Post","objectId":"abc"}}}`)
+			})
+		})
+
+		Convey("When adding full-text constraints", func() {
+
+			Convey("It compiles $text", func() {
+				q := testingClass.NewQuery().FullText("name", "apple", nil)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"name":{"$text":{"$search":{"$term":"apple"}}}}`)
+			})
+
+			Convey("It compiles $text with options", func() {
+				q := testingClass.NewQuery().FullText("name", "apple", &TextSearchOptions{
+					Language:           "en",
+					CaseSensitive:      true,
+					DiacriticSensitive: true,
+				})
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"name":{"$text":{"$search":{`+
+					`"$caseSensitive":true,"$diacriticSensitive":true,"$language":"en","$term":"apple"}}}}`)
+			})
+
+			Convey("It sorts by the score", func() {
+				vals, err := testingClass.NewQuery().FullText("name", "apple", nil).SortByTextScore().Values()
+				So(err, ShouldBeNil)
+				So(vals.Get("order"), ShouldEqual, "$score")
+				So(vals.Get("keys"), ShouldEqual, "$score")
+			})
+		})
+
+		Convey("When adding geo constraints", func() {
+
+			point := GeoPoint{Latitude: 35.5, Longitude: 139.5}
+			northeast := GeoPoint{Latitude: 36, Longitude: 140}
+
+			Convey("It compiles $nearSphere", func() {
+				q := testingClass.NewQuery().Near("location", point)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"location":{"$nearSphere":{"__type":"GeoPoint","latitude":35.5,"longitude":139.5}}}`)
+			})
+
+			Convey("It compiles $nearSphere with max distance", func() {
+				q := testingClass.NewQuery().WithinKilometers("location", point, 10)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"location":{"$maxDistanceInKilometers":10,"$nearSphere":{"__type":"GeoPoint","latitude":35.5,"longitude":139.5}}}`)
+
+				q = testingClass.NewQuery().WithinMiles("location", point, 5)
+				So(q.Where()["location"], ShouldContainKey, "$maxDistanceInMiles")
+
+				q = testingClass.NewQuery().WithinRadians("location", point, 0.1)
+				So(q.Where()["location"], ShouldContainKey, "$maxDistanceInRadians")
+			})
+
+			Convey("It compiles $within $box", func() {
+				q := testingClass.NewQuery().WithinGeoBox("location", point, northeast)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"location":{"$within":{"$box":[`+
+					`{"__type":"GeoPoint","latitude":35.5,"longitude":139.5},`+
+					`{"__type":"GeoPoint","latitude":36,"longitude":140}]}}}`)
+			})
+
+			Convey("It compiles $geoWithin $polygon", func() {
+				q := testingClass.NewQuery().WithinPolygon("location", point, northeast, GeoPoint{Latitude: 36, Longitude: 139.5})
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"location":{"$geoWithin":{"$polygon":[`+
+					`{"__type":"GeoPoint","latitude":35.5,"longitude":139.5},`+
+					`{"__type":"GeoPoint","latitude":36,"longitude":140},`+
+					`{"__type":"GeoPoint","latitude":36,"longitude":139.5}]}}}`)
+			})
+
+			Convey("It compiles $geoWithin $centerSphere", func() {
+				q := testingClass.NewQuery().WithinCenterSphere("location", point, 0.1)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"location":{"$geoWithin":{"$centerSphere":[`+
+					`{"__type":"GeoPoint","latitude":35.5,"longitude":139.5},0.1]}}}`)
+			})
+
+			Convey("It compiles $geoIntersects", func() {
+				q := testingClass.NewQuery().PolygonContains("area", point)
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"area":{"$geoIntersects":{"$point":{"__type":"GeoPoint","latitude":35.5,"longitude":139.5}}}}`)
+			})
+		})
+
+		Convey("When equality follows other constraints", func() {
+
+			q := testingClass.NewQuery().
+				GreaterThan("code", 100).
+				EqualTo("code", 201)
+
+			Convey("It keeps the constraints with $eq", func() {
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"code":{"$eq":201,"$gt":100}}`)
+			})
+		})
+
+		Convey("When other constraints follow equality", func() {
+
+			q := testingClass.NewQuery().
+				EqualTo("code", 5).
+				GreaterThan("code", 1)
+
+			Convey("It keeps the equality as $eq", func() {
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"code":{"$eq":5,"$gt":1}}`)
+			})
+		})
+
+		Convey("When other constraints follow equality to a map", func() {
+
+			value := map[string]interface{}{"lang": "ja"}
+			q := testingClass.NewQuery().
+				EqualTo("meta", value).
+				NotEqualTo("meta", nil)
+
+			Convey("It does not change the map", func() {
+				So(value, ShouldResemble, map[string]interface{}{"lang": "ja"})
+				b, _ := json.Marshal(q.Where())
+				So(string(b), ShouldEqual, `{"meta":{"$eq":{"lang":"ja"},"$ne":null}}`)
+			})
+		})
+	})
+}
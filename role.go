@@ -0,0 +1,161 @@
+package goparse
+
+import (
+	"errors"
+)
+
+const (
+	classRole = "_Role"
+	classUser = "_User"
+)
+
+// ParseRoles is the class of roles which manages the users and the child roles
+type ParseRoles struct {
+	*ParseClass
+}
+
+// NewRoles creates a roles class from the session
+func (s *ParseSession) NewRoles() *ParseRoles {
+	return &ParseRoles{
+		ParseClass: &ParseClass{
+			Session:   s,
+			Name:      classRole,
+			ClassURL:  "/roles",
+			UseMaster: false,
+		},
+	}
+}
+
+// CreateRole creates a role with the ACL. The default ACL of the session is used if acl is nil.
+func (r *ParseRoles) CreateRole(name string, acl *ACL) (role Role, err error) {
+	if name == "" {
+		return role, errors.New("role name must not be empty")
+	}
+	if acl == nil && r.Session.DefaultACL != nil {
+		acl = r.Session.DefaultACL.copy()
+	}
+	role = Role{
+		Name: name,
+		ACL:  acl,
+	}
+	data := map[string]interface{}{
+		"name": name,
+	}
+	if acl != nil {
+		data["ACL"] = acl
+	}
+	var resp ObjectResponse
+	if err := r.Create(data, &resp); err != nil {
+		return role, err
+	}
+	role.ObjectID = resp.ObjectID
+	role.CreatedAt = resp.CreatedAt
+	return role, nil
+}
+
+// GetRole gets a role by ID
+func (r *ParseRoles) GetRole(roleID string) (role Role, err error) {
+	if roleID == "" {
+		return role, errors.New("roleID must not be empty")
+	}
+	return role, r.Select(roleID, &role)
+}
+
+// GetRoleByName gets a role by name
+func (r *ParseRoles) GetRoleByName(name string) (role Role, err error) {
+	var roles []Role
+	if err := r.NewQuery().EqualTo("name", name).Limit(1).FindList(&roles); err != nil {
+		return role, err
+	}
+	if len(roles) == 0 {
+		return role, &Error{Code: errCodeObjectNotFound, Message: "role not found"}
+	}
+	return roles[0], nil
+}
+
+// AddUsers adds the users to the role
+func (r *ParseRoles) AddUsers(roleID string, userIDs ...string) error {
+	return r.updateRelation(roleID, "users", AddRelation(pointers(classUser, userIDs)...))
+}
+
+// RemoveUsers removes the users from the role
+func (r *ParseRoles) RemoveUsers(roleID string, userIDs ...string) error {
+	return r.updateRelation(roleID, "users", RemoveRelation(pointers(classUser, userIDs)...))
+}
+
+// AddChildRoles adds the child roles to the role.
+// The users in the child roles inherit the permissions of the role.
+func (r *ParseRoles) AddChildRoles(roleID string, childRoleIDs ...string) error {
+	return r.updateRelation(roleID, "roles", AddRelation(pointers(classRole, childRoleIDs)...))
+}
+
+// RemoveChildRoles removes the child roles from the role
+func (r *ParseRoles) RemoveChildRoles(roleID string, childRoleIDs ...string) error {
+	return r.updateRelation(roleID, "roles", RemoveRelation(pointers(classRole, childRoleIDs)...))
+}
+
+// UserRoles gets the roles which the user belongs to, including the roles inherited from the child roles
+func (r *ParseRoles) UserRoles(userID string) ([]Role, error) {
+	if userID == "" {
+		return nil, errors.New("userID must not be empty")
+	}
+
+	roles := []Role{}
+	found := map[string]bool{}
+	q := r.NewQuery().EqualTo("users", NewPointer(classUser, userID))
+	for q != nil {
+		var page []Role
+		if err := findAllRoles(q, &page); err != nil {
+			return nil, err
+		}
+
+		var parents []interface{}
+		for _, role := range page {
+			if found[role.ObjectID] {
+				continue
+			}
+			found[role.ObjectID] = true
+			roles = append(roles, role)
+			parents = append(parents, NewPointer(classRole, role.ObjectID))
+		}
+
+		q = nil
+		if len(parents) > 0 {
+			q = r.NewQuery().ContainedIn("roles", parents...)
+		}
+	}
+	return roles, nil
+}
+
+// DeleteRole deletes a role by ID
+func (r *ParseRoles) DeleteRole(roleID string) error {
+	return r.Delete(roleID)
+}
+
+// Update a relation of the role
+func (r *ParseRoles) updateRelation(roleID string, key string, op Operation) error {
+	if len(op.Objects) == 0 {
+		return errors.New("IDs must not be empty")
+	}
+	return r.Update(roleID, map[string]interface{}{key: op}, nil)
+}
+
+// Get all roles which match the query
+func findAllRoles(q *Query, roles *[]Role) error {
+	it := q.Iterator(0)
+	defer it.Close()
+	var role Role
+	for it.Next(&role) {
+		*roles = append(*roles, role)
+	}
+	return it.Err()
+}
+
+// Make pointers to the objects of the class
+func pointers(className string, objectIDs []string) []Pointer {
+	ps := make([]Pointer, len(objectIDs))
+	for i, id := range objectIDs {
+		ps[i] = NewPointer(className, id)
+	}
+	return ps
+}
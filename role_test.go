@@ -0,0 +1,210 @@
+package goparse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseRoles(t *testing.T) {
+
+	Convey("Given roles", t, func() {
+
+		type fakeRole struct {
+			name  string
+			users []string
+			roles []string
+		}
+		// member <- moderator <- admin <- u1
+		fakeRoles := map[string]fakeRole{
+			"r1": {name: "admin", users: []string{"u1"}},
+			"r2": {name: "moderator", roles: []string{"r1"}},
+			"r3": {name: "member", roles: []string{"r2", "r1"}},
+			"r4": {name: "other", users: []string{"u2"}},
+		}
+		ids := []string{"r1", "r2", "r3", "r4"}
+
+		var method, path string
+		var body map[string]interface{}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			path = r.URL.Path
+			body = nil
+			json.NewDecoder(r.Body).Decode(&body)
+
+			switch r.Method {
+			case "POST":
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"objectId":"r5","createdAt":"2016-10-12T08:31:59.030Z"}`))
+			case "PUT":
+				w.Write([]byte(`{"updatedAt":"2016-10-12T08:31:59.030Z"}`))
+			case "DELETE":
+				w.Write([]byte(`{}`))
+			case "GET":
+				var where struct {
+					Name     string  `json:"name"`
+					Users    Pointer `json:"users"`
+					ObjectID struct {
+						GT string `json:"$gt"`
+					} `json:"objectId"`
+					Roles struct {
+						In []Pointer `json:"$in"`
+					} `json:"roles"`
+				}
+				json.Unmarshal([]byte(r.URL.Query().Get("where")), &where)
+
+				results := []map[string]interface{}{}
+				for _, id := range ids {
+					role := fakeRoles[id]
+					if id <= where.ObjectID.GT {
+						continue
+					}
+					match := where.Name != "" && role.name == where.Name
+					for _, u := range role.users {
+						match = match || u == where.Users.ObjectID
+					}
+					for _, child := range role.roles {
+						for _, p := range where.Roles.In {
+							match = match || child == p.ObjectID
+						}
+					}
+					if match {
+						results = append(results, map[string]interface{}{"objectId": id, "name": role.name})
+					}
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		roles := client.NewSession("").NewRoles()
+
+		Convey("When creating a role", func() {
+
+			role, err := roles.CreateRole("editor", NewACL().SetPublicRead(true))
+
+			Convey("It posts the name and the ACL", func() {
+				So(err, ShouldBeNil)
+				So(role.ObjectID, ShouldEqual, "r5")
+				So(role.Name, ShouldEqual, "editor")
+				So(method, ShouldEqual, "POST")
+				So(path, ShouldEqual, "/roles")
+				So(body, ShouldResemble, map[string]interface{}{
+					"name": "editor",
+					"ACL":  map[string]interface{}{"*": map[string]interface{}{"read": true}},
+				})
+			})
+		})
+
+		Convey("When creating a role without the ACL", func() {
+
+			roles.Session.DefaultACL = NewACL().SetRoleRead("admin", true)
+			role, err := roles.CreateRole("editor", nil)
+
+			Convey("It returns the default ACL which is sent", func() {
+				So(err, ShouldBeNil)
+				So(body["ACL"], ShouldResemble, map[string]interface{}{"role:admin": map[string]interface{}{"read": true}})
+				So(role.ACL, ShouldNotBeNil)
+				So(role.ACL.RoleRead("admin"), ShouldBeTrue)
+				role.ACL.SetPublicRead(true)
+				So(roles.Session.DefaultACL.PublicRead(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When creating a role without name", func() {
+
+			_, err := roles.CreateRole("", nil)
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(method, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When getting a role by name", func() {
+
+			role, err := roles.GetRoleByName("moderator")
+			_, err2 := roles.GetRoleByName("unknown")
+
+			Convey("It returns the role", func() {
+				So(err, ShouldBeNil)
+				So(role.ObjectID, ShouldEqual, "r2")
+				So(IsObjectNotFound(err2), ShouldBeTrue)
+			})
+		})
+
+		Convey("When adding users", func() {
+
+			err := roles.AddUsers("r1", "u3", "u4")
+
+			Convey("It puts AddRelation", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "PUT")
+				So(path, ShouldEqual, "/roles/r1")
+				b, _ := json.Marshal(body)
+				So(string(b), ShouldEqual, `{"users":{"__op":"AddRelation","objects":[`+
+					`{"__type":"Pointer","className":"_User","objectId":"u3"},`+
+					`{"__type":"Pointer","className":"_User","objectId":"u4"}]}}`)
+			})
+		})
+
+		Convey("When removing child roles", func() {
+
+			err := roles.RemoveChildRoles("r2", "r1")
+
+			Convey("It puts RemoveRelation", func() {
+				So(err, ShouldBeNil)
+				So(path, ShouldEqual, "/roles/r2")
+				b, _ := json.Marshal(body)
+				So(string(b), ShouldEqual, `{"roles":{"__op":"RemoveRelation","objects":[`+
+					`{"__type":"Pointer","className":"_Role","objectId":"r1"}]}}`)
+			})
+		})
+
+		Convey("When adding no users", func() {
+
+			err := roles.AddUsers("r1")
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(method, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When getting the roles of a user", func() {
+
+			userRoles, err := roles.UserRoles("u1")
+
+			Convey("It includes the inherited roles", func() {
+				So(err, ShouldBeNil)
+				names := []string{}
+				for _, role := range userRoles {
+					names = append(names, role.Name)
+				}
+				So(strings.Join(names, ","), ShouldEqual, "admin,moderator,member")
+			})
+		})
+
+		Convey("When deleting a role", func() {
+
+			err := roles.DeleteRole("r4")
+
+			Convey("It deletes the role", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "DELETE")
+				So(path, ShouldEqual, "/roles/r4")
+			})
+		})
+	})
+}
@@ -0,0 +1,330 @@
+package goparse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// Field types of the schema
+const (
+	FieldTypeString   = "String"
+	FieldTypeNumber   = "Number"
+	FieldTypeBoolean  = "Boolean"
+	FieldTypeDate     = "Date"
+	FieldTypeObject   = "Object"
+	FieldTypeArray    = "Array"
+	FieldTypeGeoPoint = "GeoPoint"
+	FieldTypePolygon  = "Polygon"
+	FieldTypeFile     = "File"
+	FieldTypeBytes    = "Bytes"
+	FieldTypePointer  = "Pointer"
+	FieldTypeRelation = "Relation"
+	FieldTypeACL      = "ACL"
+)
+
+// Schema data type
+type Schema struct {
+	ClassName             string                 `json:"className"`
+	Fields                map[string]SchemaField `json:"fields,omitempty"`
+	ClassLevelPermissions *ClassLevelPermissions `json:"classLevelPermissions,omitempty"`
+	Indexes               map[string]Index       `json:"indexes,omitempty"`
+}
+
+// SchemaField data type. TargetClass is set for Pointer and Relation fields.
+type SchemaField struct {
+	Type         string      `json:"type"`
+	TargetClass  string      `json:"targetClass,omitempty"`
+	Required     bool        `json:"required,omitempty"`
+	DefaultValue interface{} `json:"defaultValue,omitempty"`
+}
+
+// ClassLevelPermissions data type. The operations which are nil are omitted.
+// ProtectedFields maps "*", user IDs, "role:<name>" or "userField:<field>" to the fields hidden from them.
+type ClassLevelPermissions struct {
+	Find            *ClassPermission    `json:"find,omitempty"`
+	Count           *ClassPermission    `json:"count,omitempty"`
+	Get             *ClassPermission    `json:"get,omitempty"`
+	Create          *ClassPermission    `json:"create,omitempty"`
+	Update          *ClassPermission    `json:"update,omitempty"`
+	Delete          *ClassPermission    `json:"delete,omitempty"`
+	AddField        *ClassPermission    `json:"addField,omitempty"`
+	ReadUserFields  []string            `json:"readUserFields,omitempty"`
+	WriteUserFields []string            `json:"writeUserFields,omitempty"`
+	ProtectedFields map[string][]string `json:"protectedFields,omitempty"`
+}
+
+// ClassPermission is the permission of an operation on the class.
+// PointerFields are the user pointer fields whose users are permitted.
+type ClassPermission struct {
+	Public                 bool
+	Users                  []string
+	Roles                  []string
+	RequiresAuthentication bool
+	PointerFields          []string
+}
+
+// MarshalJSON encodes ClassPermission as the map of the permitted
+func (p ClassPermission) MarshalJSON() ([]byte, error) {
+	v := map[string]interface{}{}
+	if p.Public {
+		v[aclPublic] = true
+	}
+	for _, userID := range p.Users {
+		v[userID] = true
+	}
+	for _, roleName := range p.Roles {
+		v[aclRolePrefix+roleName] = true
+	}
+	if p.RequiresAuthentication {
+		v["requiresAuthentication"] = true
+	}
+	if len(p.PointerFields) > 0 {
+		v["pointerFields"] = p.PointerFields
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes ClassPermission from the map of the permitted
+func (p *ClassPermission) UnmarshalJSON(b []byte) error {
+	v := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*p = ClassPermission{}
+	for key, raw := range v {
+		if key == "pointerFields" {
+			if err := json.Unmarshal(raw, &p.PointerFields); err != nil {
+				return err
+			}
+			continue
+		}
+		var allowed bool
+		if err := json.Unmarshal(raw, &allowed); err != nil {
+			return err
+		}
+		if !allowed {
+			continue
+		}
+		switch {
+		case key == aclPublic:
+			p.Public = true
+		case key == "requiresAuthentication":
+			p.RequiresAuthentication = true
+		case strings.HasPrefix(key, aclRolePrefix):
+			p.Roles = append(p.Roles, strings.TrimPrefix(key, aclRolePrefix))
+		default:
+			p.Users = append(p.Users, key)
+		}
+	}
+	sort.Strings(p.Users)
+	sort.Strings(p.Roles)
+	return nil
+}
+
+// Index is the keys of an index in order
+type Index []IndexKey
+
+// IndexKey is a field of an index. Value is 1, -1 or the index type such as "text" or "2dsphere".
+type IndexKey struct {
+	Field string
+	Value interface{}
+}
+
+// MarshalJSON encodes Index as the object of the keys in order
+func (index Index) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range index {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		field, err := json.Marshal(key.Field)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(key.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(field)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes Index from the object of the keys in order
+func (index *Index) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if t, err := dec.Token(); err != nil {
+		return err
+	} else if t != json.Delim('{') {
+		return errors.New("index must be an object")
+	}
+	keys := Index{}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if n, ok := value.(json.Number); ok {
+			if value, err = n.Int64(); err != nil {
+				return err
+			}
+		}
+		keys = append(keys, IndexKey{Field: t.(string), Value: value})
+	}
+	*index = keys
+	return nil
+}
+
+// ParseSchemas is the client of the schemas which manages classes by use master key
+type ParseSchemas struct {
+	session *ParseSession
+}
+
+// NewSchemas creates a schemas client from the session
+func (s *ParseSession) NewSchemas() *ParseSchemas {
+	return &ParseSchemas{
+		session: s,
+	}
+}
+
+// All gets the schemas of all the classes
+func (c *ParseSchemas) All() ([]Schema, error) {
+	if err := c.checkMasterKey(); err != nil {
+		return nil, err
+	}
+	var schemas []Schema
+	return schemas, do(c.session.get("/schemas", true), &ListResponse{Results: &schemas})
+}
+
+// Get gets the schema of the class
+func (c *ParseSchemas) Get(className string) (schema Schema, err error) {
+	if err := c.checkClass(className); err != nil {
+		return schema, err
+	}
+	return schema, do(c.session.get("/schemas/"+className, true), &schema)
+}
+
+// Create creates a class with the fields of the schema
+func (c *ParseSchemas) Create(schema Schema) (result Schema, err error) {
+	if err := c.checkClass(schema.ClassName); err != nil {
+		return result, err
+	}
+	req, err := sendJSON(c.session.post("/schemas/"+schema.ClassName, true), schema)
+	if err != nil {
+		return result, err
+	}
+	return result, do(req, &result)
+}
+
+// AddFields adds the fields to the class
+func (c *ParseSchemas) AddFields(className string, fields map[string]SchemaField) (Schema, error) {
+	return c.update(className, map[string]interface{}{
+		"fields": fields,
+	})
+}
+
+// DeleteFields deletes the fields from the class
+func (c *ParseSchemas) DeleteFields(className string, names ...string) (Schema, error) {
+	if len(names) == 0 {
+		return Schema{}, errors.New("names must not be empty")
+	}
+	fields := map[string]interface{}{}
+	for _, name := range names {
+		fields[name] = DeleteField()
+	}
+	return c.update(className, map[string]interface{}{
+		"fields": fields,
+	})
+}
+
+// UpdateClassLevelPermissions replaces the class level permissions and the protected fields of the class
+func (c *ParseSchemas) UpdateClassLevelPermissions(className string, clp ClassLevelPermissions) (Schema, error) {
+	return c.update(className, map[string]interface{}{
+		"classLevelPermissions": clp,
+	})
+}
+
+// Indexes gets the indexes of the class by name
+func (c *ParseSchemas) Indexes(className string) (map[string]Index, error) {
+	schema, err := c.Get(className)
+	if err != nil {
+		return nil, err
+	}
+	return schema.Indexes, nil
+}
+
+// AddIndex adds the index to the class
+func (c *ParseSchemas) AddIndex(className string, name string, index Index) (Schema, error) {
+	if name == "" {
+		return Schema{}, errors.New("index name must not be empty")
+	}
+	if len(index) == 0 {
+		return Schema{}, errors.New("index must have keys")
+	}
+	return c.update(className, map[string]interface{}{
+		"indexes": map[string]interface{}{
+			name: index,
+		},
+	})
+}
+
+// DropIndex drops the index from the class
+func (c *ParseSchemas) DropIndex(className string, name string) (Schema, error) {
+	if name == "" {
+		return Schema{}, errors.New("index name must not be empty")
+	}
+	return c.update(className, map[string]interface{}{
+		"indexes": map[string]interface{}{
+			name: DeleteField(),
+		},
+	})
+}
+
+// Delete deletes the class. The class must have no objects.
+func (c *ParseSchemas) Delete(className string) error {
+	if err := c.checkClass(className); err != nil {
+		return err
+	}
+	return do(c.session.del("/schemas/"+className, true), nil)
+}
+
+// Update the schema of the class
+func (c *ParseSchemas) update(className string, body map[string]interface{}) (result Schema, err error) {
+	if err := c.checkClass(className); err != nil {
+		return result, err
+	}
+	body["className"] = className
+	req, err := sendJSON(c.session.put("/schemas/"+className, true), body)
+	if err != nil {
+		return result, err
+	}
+	return result, do(req, &result)
+}
+
+// Check the class name and the master key
+func (c *ParseSchemas) checkClass(className string) error {
+	if className == "" {
+		return errors.New("className must not be empty")
+	}
+	return c.checkMasterKey()
+}
+
+// Check the master key which the schemas require
+func (c *ParseSchemas) checkMasterKey() error {
+	if c.session.client.MasterKey == "" {
+		return errors.New("request requires MasterKey")
+	}
+	return nil
+}
@@ -0,0 +1,275 @@
+package goparse
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseSchemas(t *testing.T) {
+
+	Convey("Given schemas", t, func() {
+
+		var method, path, rawBody string
+		var header http.Header
+		var body map[string]interface{}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			path = r.URL.Path
+			header = r.Header
+			b, _ := ioutil.ReadAll(r.Body)
+			rawBody = string(b)
+			body = nil
+			json.Unmarshal(b, &body)
+
+			schema := `{
+				"className": "Testdata",
+				"fields": {
+					"objectId": {"type": "String"},
+					"name": {"type": "String", "required": true},
+					"owner": {"type": "Pointer", "targetClass": "_User"},
+					"code": {"type": "Number", "defaultValue": 0}
+				},
+				"indexes": {
+					"_id_": {"_id": 1},
+					"name_code": {"name": 1, "code": -1},
+					"name_text": {"name": "text"}
+				}
+			}`
+			switch {
+			case r.Method == "GET" && r.URL.Path == "/schemas":
+				w.Write([]byte(`{"results":[` + schema + `,{"className":"_User","fields":{}}]}`))
+			case r.Method == "DELETE":
+				w.Write([]byte(`{}`))
+			default:
+				w.Write([]byte(schema))
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			MasterKey:     "MASTERKEY",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		schemas := client.NewSession("").NewSchemas()
+
+		Convey("When listing all schemas", func() {
+
+			all, err := schemas.All()
+
+			Convey("It returns the schemas with the master key", func() {
+				So(err, ShouldBeNil)
+				So(len(all), ShouldEqual, 2)
+				So(all[0].ClassName, ShouldEqual, "Testdata")
+				So(all[1].ClassName, ShouldEqual, "_User")
+				So(header.Get(headerMasterKey), ShouldEqual, "MASTERKEY")
+			})
+		})
+
+		Convey("When getting a schema", func() {
+
+			schema, err := schemas.Get("Testdata")
+
+			Convey("It returns the typed fields", func() {
+				So(err, ShouldBeNil)
+				So(path, ShouldEqual, "/schemas/Testdata")
+				So(schema.Fields["name"], ShouldResemble, SchemaField{Type: FieldTypeString, Required: true})
+				So(schema.Fields["owner"], ShouldResemble, SchemaField{Type: FieldTypePointer, TargetClass: "_User"})
+				So(schema.Fields["code"].DefaultValue, ShouldEqual, 0)
+			})
+		})
+
+		Convey("When creating a class", func() {
+
+			_, err := schemas.Create(Schema{
+				ClassName: "Testdata",
+				Fields: map[string]SchemaField{
+					"name":  {Type: FieldTypeString, Required: true},
+					"owner": {Type: FieldTypePointer, TargetClass: "_User"},
+				},
+			})
+
+			Convey("It posts the fields", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "POST")
+				So(path, ShouldEqual, "/schemas/Testdata")
+				So(body, ShouldResemble, map[string]interface{}{
+					"className": "Testdata",
+					"fields": map[string]interface{}{
+						"name":  map[string]interface{}{"type": "String", "required": true},
+						"owner": map[string]interface{}{"type": "Pointer", "targetClass": "_User"},
+					},
+				})
+			})
+		})
+
+		Convey("When adding fields", func() {
+
+			_, err := schemas.AddFields("Testdata", map[string]SchemaField{
+				"location": {Type: FieldTypeGeoPoint},
+			})
+
+			Convey("It puts the fields", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "PUT")
+				So(body, ShouldResemble, map[string]interface{}{
+					"className": "Testdata",
+					"fields": map[string]interface{}{
+						"location": map[string]interface{}{"type": "GeoPoint"},
+					},
+				})
+			})
+		})
+
+		Convey("When deleting fields", func() {
+
+			_, err := schemas.DeleteFields("Testdata", "code")
+
+			Convey("It puts the delete operations", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "PUT")
+				So(body, ShouldResemble, map[string]interface{}{
+					"className": "Testdata",
+					"fields": map[string]interface{}{
+						"code": map[string]interface{}{"__op": "Delete"},
+					},
+				})
+			})
+		})
+
+		Convey("When updating class level permissions", func() {
+
+			_, err := schemas.UpdateClassLevelPermissions("Testdata", ClassLevelPermissions{
+				Find:     &ClassPermission{Public: true},
+				Get:      &ClassPermission{RequiresAuthentication: true},
+				Update:   &ClassPermission{Users: []string{"u1"}, Roles: []string{"admin"}, PointerFields: []string{"owner"}},
+				Delete:   &ClassPermission{},
+				AddField: &ClassPermission{Roles: []string{"admin"}},
+				ProtectedFields: map[string][]string{
+					"*":          {"email"},
+					"role:admin": {},
+				},
+				ReadUserFields: []string{"owner"},
+			})
+
+			Convey("It puts the permissions", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "PUT")
+				So(path, ShouldEqual, "/schemas/Testdata")
+				b, _ := json.Marshal(body)
+				So(string(b), ShouldEqual, `{"classLevelPermissions":{`+
+					`"addField":{"role:admin":true},`+
+					`"delete":{},`+
+					`"find":{"*":true},`+
+					`"get":{"requiresAuthentication":true},`+
+					`"protectedFields":{"*":["email"],"role:admin":[]},`+
+					`"readUserFields":["owner"],`+
+					`"update":{"pointerFields":["owner"],"role:admin":true,"u1":true}},`+
+					`"className":"Testdata"}`)
+			})
+		})
+
+		Convey("When listing indexes", func() {
+
+			indexes, err := schemas.Indexes("Testdata")
+
+			Convey("It returns the keys in order", func() {
+				So(err, ShouldBeNil)
+				So(len(indexes), ShouldEqual, 3)
+				So(indexes["name_code"], ShouldResemble, Index{{"name", int64(1)}, {"code", int64(-1)}})
+				So(indexes["name_text"], ShouldResemble, Index{{"name", "text"}})
+			})
+		})
+
+		Convey("When adding an index", func() {
+
+			_, err := schemas.AddIndex("Testdata", "code_name", Index{{"code", -1}, {"name", 1}})
+
+			Convey("It puts the keys in order", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "PUT")
+				So(rawBody, ShouldEqual, `{"className":"Testdata","indexes":{"code_name":{"code":-1,"name":1}}}`)
+			})
+		})
+
+		Convey("When adding an index without keys", func() {
+
+			_, err := schemas.AddIndex("Testdata", "empty", Index{})
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(method, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When dropping an index", func() {
+
+			_, err := schemas.DropIndex("Testdata", "name_code")
+
+			Convey("It puts the delete operation", func() {
+				So(err, ShouldBeNil)
+				So(rawBody, ShouldEqual, `{"className":"Testdata","indexes":{"name_code":{"__op":"Delete"}}}`)
+			})
+		})
+
+		Convey("When deleting a class", func() {
+
+			err := schemas.Delete("Testdata")
+
+			Convey("It deletes the schema", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "DELETE")
+				So(path, ShouldEqual, "/schemas/Testdata")
+			})
+		})
+
+		Convey("When the master key is empty", func() {
+
+			client.MasterKey = ""
+			_, err := schemas.Get("Testdata")
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "request requires MasterKey")
+				So(method, ShouldBeEmpty)
+			})
+		})
+	})
+
+	Convey("Given JSON strings", t, func() {
+
+		Convey("When decoding as ClassLevelPermissions", func() {
+
+			s := `{
+				"find": {"*": true},
+				"count": {"requiresAuthentication": true},
+				"get": {"u2": true, "u1": true, "role:admin": true, "u3": false},
+				"update": {"pointerFields": ["owner"]},
+				"delete": {},
+				"protectedFields": {"*": ["email", "phone"]}
+			}`
+
+			var clp ClassLevelPermissions
+			err := json.Unmarshal([]byte(s), &clp)
+			So(err, ShouldBeNil)
+
+			Convey("It has the typed permissions", func() {
+				So(*clp.Find, ShouldResemble, ClassPermission{Public: true})
+				So(*clp.Count, ShouldResemble, ClassPermission{RequiresAuthentication: true})
+				So(*clp.Get, ShouldResemble, ClassPermission{Users: []string{"u1", "u2"}, Roles: []string{"admin"}})
+				So(*clp.Update, ShouldResemble, ClassPermission{PointerFields: []string{"owner"}})
+				So(*clp.Delete, ShouldResemble, ClassPermission{})
+				So(clp.Create, ShouldBeNil)
+				So(clp.ProtectedFields["*"], ShouldResemble, []string{"email", "phone"})
+			})
+		})
+	})
+}
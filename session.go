@@ -16,14 +16,19 @@ const (
 	headerAPIKey           = "X-Parse-REST-API-Key"      // Parse REST API Key
 	headerSessionToken     = "X-Parse-Session-Token"     // Parse Session Token
 	headerRevocableSession = "X-Parse-Revocable-Session" // Parse Session Token
+	headerJobStatusID      = "X-Parse-Job-Status-Id"     // Parse Job Status ID
+	headerInstallationID   = "X-Parse-Installation-Id"   // Parse Installation ID
 
-	pathMe = "/users/me"
+	pathMe        = "/users/me"
+	pathSessionMe = "/sessions/me"
 )
 
 // ParseSession is the client which has SessionToken as user authentication.
+// DefaultACL is set to data created by the classes of the session if the data has no ACL.
 type ParseSession struct {
 	client       *ParseClient
 	SessionToken string
+	DefaultACL   *ACL
 }
 
 const (
@@ -209,6 +214,17 @@ func (s *ParseSession) PushNotificationByMaster(body PushNotificationQuery) erro
 	return do(s.post("/push", true).Send(body), nil)
 }
 
+// Set the data to the request body as JSON marshaled by encoding/json.
+// Unlike Send, the body is sent as it is, so the order of the keys such as the struct fields is kept.
+func sendJSON(req *gorequest.SuperAgent, data interface{}) (*gorequest.SuperAgent, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	req.BounceToRawString = true
+	return req.SendString(string(b)), nil
+}
+
 // Execute a parse request
 func do(req *gorequest.SuperAgent, data interface{}) error {
 
@@ -216,7 +232,12 @@ func do(req *gorequest.SuperAgent, data interface{}) error {
 	if errs != nil {
 		return fmt.Errorf("%v", errs)
 	}
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
+	return decodeResponse(res.StatusCode, body, data)
+}
+
+// Decode the response body into data, or into an error if the status is not successful
+func decodeResponse(statusCode int, body string, data interface{}) error {
+	if statusCode < 200 || statusCode >= 300 {
 		// parse as error model
 		reserr := new(Error)
 		err := json.NewDecoder(strings.NewReader(body)).Decode(reserr)
@@ -0,0 +1,89 @@
+package goparse
+
+import "errors"
+
+const classSession = "_Session"
+
+// GetCurrentSession gets the session of the session token
+func (s *ParseSession) GetCurrentSession() (session Session, err error) {
+	return session, do(s.get(pathSessionMe, false), &session)
+}
+
+// GetSessionsByMaster gets all the sessions of the user by use master key.
+// The sessions are fetched page by page, so they are not limited to a page.
+func (s *ParseSession) GetSessionsByMaster(userObjectID string) ([]Session, error) {
+	if userObjectID == "" {
+		return nil, errors.New("userObjectID must not be empty")
+	}
+	if s.client.MasterKey == "" {
+		return nil, errors.New("request requires MasterKey")
+	}
+	class := &ParseClass{
+		Session:   s,
+		Name:      classSession,
+		ClassURL:  "/sessions",
+		UseMaster: true,
+	}
+	it := class.NewQuery().EqualTo("user", NewPointer(classUser, userObjectID)).Iterator(0)
+	defer it.Close()
+	sessions := []Session{}
+	var session Session
+	for it.Next(&session) {
+		sessions = append(sessions, session)
+	}
+	return sessions, it.Err()
+}
+
+// RevokeSession deletes the session by ID
+func (s *ParseSession) RevokeSession(sessionObjectID string) error {
+	return s.revokeSession(sessionObjectID, false)
+}
+
+// RevokeSessionByMaster deletes the session by ID by use master key
+func (s *ParseSession) RevokeSessionByMaster(sessionObjectID string) error {
+	return s.revokeSession(sessionObjectID, true)
+}
+
+// Delete the session by private
+func (s *ParseSession) revokeSession(sessionObjectID string, useMaster bool) error {
+	if sessionObjectID == "" {
+		return errors.New("sessionObjectID must not be empty")
+	}
+	if useMaster && s.client.MasterKey == "" {
+		return errors.New("request requires MasterKey")
+	}
+	return do(s.del("/sessions/"+sessionObjectID, useMaster), nil)
+}
+
+// UpdateSessionInstallation pairs the current session with the installation.
+// The installation ID is sent by the header to the session of its object ID,
+// because the server rejects installationId in the body of a session update.
+func (s *ParseSession) UpdateSessionInstallation(installationID string) (*ObjectResponse, error) {
+	if installationID == "" {
+		return nil, errors.New("installationID must not be empty")
+	}
+	session, err := s.GetCurrentSession()
+	if err != nil {
+		return nil, err
+	}
+	req := s.put("/sessions/"+session.ObjectID, false).Set(headerInstallationID, installationID)
+	req, err = sendJSON(req, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	var resp ObjectResponse
+	return &resp, do(req, &resp)
+}
+
+// UpgradeToRevocableSession upgrades the legacy session token to a revocable session,
+// and replaces the session token by the new one
+func (s *ParseSession) UpgradeToRevocableSession() (session Session, err error) {
+	if s.SessionToken == "" {
+		return session, errors.New("SessionToken must not be empty")
+	}
+	err = do(s.post("/upgradeToRevocableSession", false), &session)
+	if session.SessionToken != "" {
+		s.SessionToken = session.SessionToken
+	}
+	return session, err
+}
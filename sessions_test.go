@@ -0,0 +1,192 @@
+package goparse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSessions(t *testing.T) {
+
+	Convey("Given a session", t, func() {
+
+		var method, path, where string
+		var header http.Header
+		var body map[string]interface{}
+		total, requests := 0, 0
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			path = r.URL.Path
+			where = r.URL.Query().Get("where")
+			header = r.Header
+			body = nil
+			json.NewDecoder(r.Body).Decode(&body)
+
+			switch {
+			case r.Method == "GET" && r.URL.Path == "/sessions/me":
+				w.Write([]byte(`{
+					"objectId": "s1",
+					"sessionToken": "r:abc",
+					"user": {"__type": "Pointer", "className": "_User", "objectId": "u1"},
+					"createdWith": {"action": "login", "authProvider": "password"},
+					"restricted": false,
+					"expiresAt": {"__type": "Date", "iso": "2017-10-12T08:31:59.030Z"},
+					"installationId": "i1",
+					"createdAt": "2016-10-12T08:31:59.030Z",
+					"updatedAt": "2016-10-12T08:31:59.030Z"
+				}`))
+			case r.Method == "GET" && total > 0:
+				requests++
+				var cond struct {
+					ObjectID struct {
+						Gt string `json:"$gt"`
+					} `json:"objectId"`
+				}
+				json.Unmarshal([]byte(where), &cond)
+				results := []map[string]string{}
+				for i := 1; i <= total && len(results) < defaultPageSize; i++ {
+					if id := fmt.Sprintf("s%03d", i); id > cond.ObjectID.Gt {
+						results = append(results, map[string]string{"objectId": id})
+					}
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+			case r.Method == "GET":
+				w.Write([]byte(`{"results":[{"objectId":"s1"},{"objectId":"s2"}]}`))
+			case r.Method == "POST":
+				w.Write([]byte(`{"objectId":"s3","sessionToken":"r:new"}`))
+			case r.Method == "PUT" && r.URL.Path == "/sessions/me":
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"code":101,"error":"Object not found."}`))
+			case r.Method == "PUT" && body["installationId"] != nil:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":105,"error":"Cannot change installationId."}`))
+			case r.Method == "PUT":
+				w.Write([]byte(`{"updatedAt":"2016-10-12T08:31:59.030Z"}`))
+			case r.Method == "DELETE":
+				w.Write([]byte(`{}`))
+			}
+		}))
+		defer server.Close()
+
+		client, err := NewClientWithConfig(ParseConfig{
+			ApplicationID: "APPID",
+			MasterKey:     "MASTERKEY",
+			URL:           server.URL,
+		})
+		So(err, ShouldBeNil)
+
+		session := client.NewSession("r:abc")
+
+		Convey("When getting the current session", func() {
+
+			s, err := session.GetCurrentSession()
+
+			Convey("It returns the session", func() {
+				So(err, ShouldBeNil)
+				So(header.Get(headerSessionToken), ShouldEqual, "r:abc")
+				So(s.ObjectID, ShouldEqual, "s1")
+				So(s.SessionToken, ShouldEqual, "r:abc")
+				So(s.User.ObjectID, ShouldEqual, "u1")
+				So(s.CreatedWith.Action, ShouldEqual, "login")
+				So(s.InstallationID, ShouldEqual, "i1")
+				expiresAt, _ := time.Parse(time.RFC3339, "2017-10-12T08:31:59.030Z")
+				So(s.ExpiresAt.Equal(expiresAt), ShouldBeTrue)
+			})
+		})
+
+		Convey("When listing sessions of a user", func() {
+
+			sessions, err := session.GetSessionsByMaster("u1")
+
+			Convey("It queries by the user pointer with the master key", func() {
+				So(err, ShouldBeNil)
+				So(len(sessions), ShouldEqual, 2)
+				So(sessions[1].ObjectID, ShouldEqual, "s2")
+				So(path, ShouldEqual, "/sessions")
+				So(where, ShouldEqual, `{"user":{"__type":"Pointer","className":"_User","objectId":"u1"}}`)
+				So(header.Get(headerMasterKey), ShouldEqual, "MASTERKEY")
+			})
+		})
+
+		Convey("When listing more sessions than a page", func() {
+
+			total = 150
+			sessions, err := session.GetSessionsByMaster("u1")
+
+			Convey("It gets all the sessions page by page", func() {
+				So(err, ShouldBeNil)
+				So(len(sessions), ShouldEqual, 150)
+				So(sessions[149].ObjectID, ShouldEqual, "s150")
+				So(requests, ShouldEqual, 2)
+				So(where, ShouldEqual, `{"objectId":{"$gt":"s100"},"user":{"__type":"Pointer","className":"_User","objectId":"u1"}}`)
+			})
+		})
+
+		Convey("When listing sessions without the master key", func() {
+
+			client.MasterKey = ""
+			_, err := session.GetSessionsByMaster("u1")
+
+			Convey("It returns an error", func() {
+				So(err, ShouldNotBeNil)
+				So(method, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When revoking a session", func() {
+
+			err := session.RevokeSession("s2")
+
+			Convey("It deletes the session", func() {
+				So(err, ShouldBeNil)
+				So(method, ShouldEqual, "DELETE")
+				So(path, ShouldEqual, "/sessions/s2")
+			})
+		})
+
+		Convey("When updating the installation", func() {
+
+			resp, err := session.UpdateSessionInstallation("i2")
+
+			Convey("It puts the installation ID header to the current session", func() {
+				So(err, ShouldBeNil)
+				So(resp.UpdatedAt.Unix(), ShouldBeGreaterThan, 0)
+				So(method, ShouldEqual, "PUT")
+				So(path, ShouldEqual, "/sessions/s1")
+				So(header.Get(headerInstallationID), ShouldEqual, "i2")
+				So(header.Get(headerSessionToken), ShouldEqual, "r:abc")
+				So(body, ShouldResemble, map[string]interface{}{})
+			})
+		})
+
+		Convey("When upgrading to a revocable session", func() {
+
+			s, err := session.UpgradeToRevocableSession()
+
+			Convey("It replaces the session token", func() {
+				So(err, ShouldBeNil)
+				So(path, ShouldEqual, "/upgradeToRevocableSession")
+				So(header.Get(headerSessionToken), ShouldEqual, "r:abc")
+				So(s.SessionToken, ShouldEqual, "r:new")
+				So(session.SessionToken, ShouldEqual, "r:new")
+			})
+		})
+	})
+
+	Convey("Given a Date", t, func() {
+
+		d := Date{time.Date(2016, 10, 12, 8, 31, 59, 30000000, time.UTC)}
+		b, err := json.Marshal(d)
+		So(err, ShouldBeNil)
+
+		Convey("It has a __type and iso", func() {
+			So(string(b), ShouldEqual, `{"__type":"Date","iso":"2016-10-12T08:31:59.030Z"}`)
+		})
+	})
+}